@@ -0,0 +1,98 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command summarygen computes propagation summaries for the exported functions
+// and methods of the packages matching its arguments, e.g.
+//
+//	summarygen -format=config example.com/lib/...
+//
+// Summaries are written to standard output, either as entries of the
+// summary.FuncSummaries map (-format=go) or as the Summaries of a configuration
+// (-format=config). Summaries that may not be precise are marked for review.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/summarygen"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+var (
+	format     = flag.String("format", "go", `output format, "go" or "config"`)
+	configFile = flag.String("config", "", "path to an analysis configuration file, whose sanitizers and summaries are used during propagation")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: summarygen [flags] packages...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if err := run(flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "summarygen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(patterns []string) error {
+	if len(patterns) == 0 {
+		return fmt.Errorf("no packages to summarize")
+	}
+	if *format != "go" && *format != "config" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	conf, err := readConfig()
+	if err != nil {
+		return err
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax}, patterns...)
+	if err != nil {
+		return err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return fmt.Errorf("packages contain errors")
+	}
+	prog, ssaPkgs := ssautil.AllPackages(pkgs, ssa.BuilderMode(0))
+	prog.Build()
+
+	var results []summarygen.Result
+	for _, p := range ssaPkgs {
+		results = append(results, summarygen.Package(conf, p)...)
+	}
+	if *format == "config" {
+		fmt.Print(summarygen.FormatConfig(results))
+	} else {
+		fmt.Print(summarygen.FormatGo(results))
+	}
+	return nil
+}
+
+func readConfig() (*config.Config, error) {
+	if *configFile == "" {
+		return config.Parse(nil)
+	}
+	b, err := ioutil.ReadFile(*configFile)
+	if err != nil {
+		return nil, err
+	}
+	return config.Parse(b)
+}
@@ -0,0 +1,166 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+const allowConfig = `
+Allow:
+- Source:
+    Package: core
+    Type: Credentials
+    Field: Token
+  Sink:
+    Package: core
+    Method: Send
+  Function:
+    Package: example.com/auth
+  Reason: tokens are sent to the authentication server
+- Source:
+    Package: core
+    Type: Credentials
+  Sink:
+    Package: core
+    Method: Log
+  Reason: logs are not collected
+`
+
+func TestAllowingRule(t *testing.T) {
+	conf, err := Parse([]byte(allowConfig))
+	if err != nil {
+		t.Fatalf("unexpected error parsing allow rules: %v", err)
+	}
+
+	tests := []struct {
+		desc string
+		flow Flow
+		want int
+	}{
+		{
+			desc: "field flow in scope",
+			flow: Flow{SourcePath: "core", SourceType: "Credentials", SourceField: "Token", SinkPath: "core", SinkName: "Send", FunctionPath: "example.com/auth", FunctionName: "Login"},
+			want: 0,
+		},
+		{
+			desc: "other field",
+			flow: Flow{SourcePath: "core", SourceType: "Credentials", SourceField: "Password", SinkPath: "core", SinkName: "Send", FunctionPath: "example.com/auth", FunctionName: "Login"},
+			want: -1,
+		},
+		{
+			desc: "whole value is not matched by a field rule",
+			flow: Flow{SourcePath: "core", SourceType: "Credentials", SinkPath: "core", SinkName: "Send", FunctionPath: "example.com/auth", FunctionName: "Login"},
+			want: -1,
+		},
+		{
+			desc: "out of scope",
+			flow: Flow{SourcePath: "core", SourceType: "Credentials", SourceField: "Token", SinkPath: "core", SinkName: "Send", FunctionPath: "example.com/server", FunctionName: "Handle"},
+			want: -1,
+		},
+		{
+			desc: "rule without field or function",
+			flow: Flow{SourcePath: "core", SourceType: "Credentials", SourceField: "Password", SinkPath: "core", SinkName: "Log", FunctionPath: "example.com/server", FunctionName: "Handle"},
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := conf.AllowingRule(tt.flow); got != tt.want {
+				t.Errorf("AllowingRule(%+v) == %d, want %d", tt.flow, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopedPackage(t *testing.T) {
+	conf, err := Parse([]byte(allowConfig + `
+- Source:
+    Package: core
+    Type: Credentials
+  Sink:
+    Package: core
+    Method: Log
+  Function:
+    PackageRE: ^example.com/
+  Reason: logs are not collected
+`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing allow rules: %v", err)
+	}
+
+	if pkg, ok := conf.ScopedPackage(0); !ok || pkg != "example.com/auth" {
+		t.Errorf("ScopedPackage(0) == %q, %v, want %q, true", pkg, ok, "example.com/auth")
+	}
+	if _, ok := conf.ScopedPackage(1); ok {
+		t.Error("rule 1 has no Function and should not be scoped to a package")
+	}
+	if _, ok := conf.ScopedPackage(2); ok {
+		t.Error("rule 2 has a PackageRE and should not be scoped to a single package")
+	}
+}
+
+func TestAllowRuleErrorCases(t *testing.T) {
+	tests := []struct {
+		desc string
+		yaml string
+	}{
+		{
+			desc: "missing reason",
+			yaml: `
+Allow:
+- Source: {Package: core}
+  Sink: {Package: core}`,
+		},
+		{
+			desc: "blank reason",
+			yaml: `
+Allow:
+- Source: {Package: core}
+  Sink: {Package: core}
+  Reason: "  "`,
+		},
+		{
+			desc: "missing source",
+			yaml: `
+Allow:
+- Sink: {Package: core}
+  Reason: reviewed`,
+		},
+		{
+			desc: "missing sink",
+			yaml: `
+Allow:
+- Source: {Package: core}
+  Reason: reviewed`,
+		},
+		{
+			desc: "invalid rule in profile",
+			yaml: `
+Profiles:
+- Name: injection
+  Allow:
+  - Source: {Package: core}
+    Sink: {Package: core}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.yaml)); err == nil {
+				t.Error("got err = nil, want error")
+			}
+		})
+	}
+}
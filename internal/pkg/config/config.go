@@ -18,8 +18,12 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
 	"io/ioutil"
-	"reflect"
+	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -52,40 +56,218 @@ func SetBytes(b []byte) {
 }
 
 // Config contains matchers and analysis scope information.
+// A Config may define Profiles, each of which is a named Config
+// that is evaluated independently, e.g. to detect both sensitive data
+// reaching logs and untrusted input reaching SQL queries in the same run.
+// Profiles share the FieldTags and Summaries of the top-level Config.
 type Config struct {
+	Name                      string
+	Profiles                  []*Config
 	ReportMessage             string
 	Sources                   []sourceMatcher
-	Sinks                     []funcMatcher
+	SourceFunctions           []sourceFuncMatcher
+	SourceMapLookups          []mapLookupMatcher
+	SourceParameters          []paramMatcher
+	SourceVariables           []variableMatcher
+	SourceInterfaces          []interfaceMatcher
+	Sinks                     []sinkMatcher
 	Sanitizers                []funcMatcher
+	Summaries                 []summaryMatcher
 	FieldTags                 []fieldTagMatcher
+	SafeFieldTags             []fieldTagMatcher
+	ProtoFieldOptions         []protoOptionMatcher
 	Exclude                   []funcMatcher
+	ExcludeFiles              []string
+	ExcludeGenerated          bool
+	ExcludeTests              bool
 	AllowPanicOnTaintedValues bool
+	AllowRedactedFormatting   bool
+	SensitiveKeyRE            *regexp.Regexp
+	ReportEscapes             bool
+	SourceHandlingPackageRE   *regexp.Regexp
+	Overrides                 []override
+	Allow                     []allowRule
+	SourceHeuristics          []sourceHeuristic
+	SourceSuggestionThreshold int
+
+	// These are set by ForPackage, according to the Overrides that apply to a package.
+	// They cannot be configured directly.
+	RemovedSources    []sourceMatcher `json:"-"`
+	RemovedSinks      []funcMatcher   `json:"-"`
+	RemovedSanitizers []funcMatcher   `json:"-"`
+
+	// These are set by ForPackage, for the package being analyzed.
+	// The named SourceInterfaces are resolved from pkg and its transitive imports,
+	// and whether a type implements one of them is recorded in implements.
+	pkg        *types.Package
+	implements map[*types.Named]bool
+}
+
+// An override changes the configuration for the packages whose path matches PackageRE,
+// e.g. to allow test utilities or command-line tools to print values that servers must not log.
+// Removing a source, sink or sanitizer takes precedence over adding one.
+type override struct {
+	PackageRE                 *regexp.Regexp
+	AddSources                []sourceMatcher
+	RemoveSources             []sourceMatcher
+	AddSinks                  []sinkMatcher
+	RemoveSinks               []funcMatcher
+	AddSanitizers             []funcMatcher
+	RemoveSanitizers          []funcMatcher
+	AllowPanicOnTaintedValues *bool
+	ReportMessage             *string
+}
+
+// An allowRule waives reports of flows that are intentional and have been reviewed,
+// e.g. a token reaching an HTTP client in an authentication package.
+// A flow is allowed if its source matches Source, its sink matches Sink and,
+// if Function is provided, the function in which the source reaches the sink matches Function.
+// If Source specifies a field, only sources that are accesses to a matching field are allowed.
+// A Reason must be provided.
+type allowRule struct {
+	Source   *sourceMatcher
+	Sink     *funcMatcher
+	Function *funcMatcher
+	Reason   string
+}
+
+// A Flow describes a source reaching a sink within a function.
+// The source is described by the package and name of its type and, if the source
+// is a field access, the name of the field. The sink and the function are described
+// by their package, receiver and name, as for sinks.
+type Flow struct {
+	SourcePath, SourceType, SourceField          string
+	SinkPath, SinkReceiver, SinkName             string
+	FunctionPath, FunctionReceiver, FunctionName string
+}
+
+func (ar allowRule) MatchFlow(f Flow) bool {
+	if f.SourceField == "" {
+		if _, allFields := ar.Source.Field.(vacuousMatcher); !allFields {
+			return false
+		}
+	}
+	return ar.Source.MatchField(f.SourcePath, f.SourceType, f.SourceField) &&
+		ar.Sink.MatchFunction(f.SinkPath, f.SinkReceiver, f.SinkName) &&
+		(ar.Function == nil || ar.Function.MatchFunction(f.FunctionPath, f.FunctionReceiver, f.FunctionName))
+}
+
+// AllowingRule returns the index of the first Allow rule that matches a flow,
+// or -1 if the flow is not allowed.
+func (c Config) AllowingRule(f Flow) int {
+	for i, ar := range c.Allow {
+		if ar.MatchFlow(f) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ScopedPackage returns the package that the Allow rule at the given index is scoped to,
+// i.e. the Package of its Function, if any. Such a rule only matches flows within that package.
+// Rules whose Function has a PackageRE are not scoped to a single package.
+func (c Config) ScopedPackage(rule int) (string, bool) {
+	fm := c.Allow[rule].Function
+	if fm == nil {
+		return "", false
+	}
+	lm, ok := fm.Package.(*literalMatcher)
+	if !ok {
+		return "", false
+	}
+	return string(*lm), true
+}
+
+// ForPackage returns the effective configuration for a package being analyzed,
+// i.e. a copy of the configuration with the Overrides that match the package's path applied, in order.
+// Since the copy resolves named SourceInterfaces from the package's imports,
+// it should only be used while analyzing that package.
+func (c *Config) ForPackage(pkg *types.Package) *Config {
+	eff := *c
+	eff.pkg = pkg
+	eff.implements = map[*types.Named]bool{}
+	for _, o := range c.Overrides {
+		if !o.PackageRE.MatchString(pkg.Path()) {
+			continue
+		}
+		// Use full slice expressions to ensure that the original slices are not modified.
+		eff.Sources = append(eff.Sources[:len(eff.Sources):len(eff.Sources)], o.AddSources...)
+		eff.Sinks = append(eff.Sinks[:len(eff.Sinks):len(eff.Sinks)], o.AddSinks...)
+		eff.Sanitizers = append(eff.Sanitizers[:len(eff.Sanitizers):len(eff.Sanitizers)], o.AddSanitizers...)
+		eff.RemovedSources = append(eff.RemovedSources[:len(eff.RemovedSources):len(eff.RemovedSources)], o.RemoveSources...)
+		eff.RemovedSinks = append(eff.RemovedSinks[:len(eff.RemovedSinks):len(eff.RemovedSinks)], o.RemoveSinks...)
+		eff.RemovedSanitizers = append(eff.RemovedSanitizers[:len(eff.RemovedSanitizers):len(eff.RemovedSanitizers)], o.RemoveSanitizers...)
+		if o.AllowPanicOnTaintedValues != nil {
+			eff.AllowPanicOnTaintedValues = *o.AllowPanicOnTaintedValues
+		}
+		if o.ReportMessage != nil {
+			eff.ReportMessage = *o.ReportMessage
+		}
+	}
+	return &eff
+}
+
+// WithProfiles returns the Config followed by each of its Profiles.
+func (c *Config) WithProfiles() []*Config {
+	return append([]*Config{c}, c.Profiles...)
+}
+
+// IsSensitiveKey determines whether the key of a structured logging attribute,
+// e.g. the "password" in slog.String("password", value), denotes sensitive data.
+func (c Config) IsSensitiveKey(key string) bool {
+	return c.SensitiveKeyRE != nil && c.SensitiveKeyRE.MatchString(key)
+}
+
+// IsSourceHandlingPackage determines whether a package is expected to hand sources to its callers,
+// in which case sources escaping through its exported functions are not reported.
+func (c Config) IsSourceHandlingPackage(path string) bool {
+	return c.SourceHandlingPackageRE != nil && c.SourceHandlingPackageRE.MatchString(path)
 }
 
 // IsSourceFieldTag determines whether a field tag made up of a key and value
 // is a Source.
 func (c Config) IsSourceFieldTag(tag string) bool {
-	if unq, err := strconv.Unquote(tag); err == nil {
-		tag = unq
-	}
-	st := reflect.StructTag(tag)
-
+	pairs := parseTag(tag)
 	// built in
-	if st.Get("levee") == "source" {
+	if builtinSourceTag.MatchTag(pairs) {
 		return true
 	}
 	// configured
 	for _, ft := range c.FieldTags {
-		val := st.Get(ft.Key)
-		for _, v := range strings.Split(val, ",") {
-			if v == ft.Value {
-				return true
-			}
+		if ft.MatchTag(pairs) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSafeFieldTag determines whether a field tag marks a field as not sensitive,
+// even if the field belongs to a Source type.
+func (c Config) IsSafeFieldTag(tag string) bool {
+	pairs := parseTag(tag)
+	// built in
+	for _, ft := range builtinSafeTags {
+		if ft.MatchTag(pairs) {
+			return true
+		}
+	}
+	// configured
+	for _, ft := range c.SafeFieldTags {
+		if ft.MatchTag(pairs) {
+			return true
 		}
 	}
 	return false
 }
 
+var (
+	builtinSourceTag = fieldTagMatcher{Key: literalMatcher("levee"), Value: literalMatcher("source")}
+	builtinSafeTags  = []fieldTagMatcher{
+		{Key: literalMatcher("levee"), Value: literalMatcher("-")},
+		{Key: literalMatcher("levee"), Value: literalMatcher("safe")},
+	}
+)
+
 // IsExcluded determines if a function matches one of the exclusion patterns.
 func (c Config) IsExcluded(path, recv, name string) bool {
 	for _, exc := range c.Exclude {
@@ -96,8 +278,67 @@ func (c Config) IsExcluded(path, recv, name string) bool {
 	return false
 }
 
+// IsExcludedFile determines whether a file is excluded from analysis,
+// based on its path and on whether it was generated.
+func (c Config) IsExcludedFile(filename string, generated bool) bool {
+	if c.ExcludeTests && strings.HasSuffix(filename, "_test.go") || c.ExcludeGenerated && generated {
+		return true
+	}
+	for _, pattern := range c.ExcludeFiles {
+		if matchFileGlob(pattern, filename) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFileGlob determines whether a glob matches the path of a file.
+// A glob without a separator, e.g. "*.pb.go", is matched against the file's name,
+// and a glob with separators, e.g. "internal/gen/*.go", against the trailing elements of its path.
+func matchFileGlob(pattern, filename string) bool {
+	elems := strings.Split(filepath.ToSlash(filename), "/")
+	n := strings.Count(pattern, "/") + 1
+	if n > len(elems) {
+		return false
+	}
+	matched, _ := path.Match(pattern, strings.Join(elems[len(elems)-n:], "/"))
+	return matched
+}
+
+// ExcludedFiles returns the files of a package that are excluded from analysis.
+func (c Config) ExcludedFiles(fset *token.FileSet, files []*ast.File) map[*token.File]bool {
+	excluded := map[*token.File]bool{}
+	for _, f := range files {
+		tf := fset.File(f.Pos())
+		if tf != nil && c.IsExcludedFile(tf.Name(), isGenerated(f)) {
+			excluded[tf] = true
+		}
+	}
+	return excluded
+}
+
+// isGenerated determines whether a file was generated, i.e. whether it has
+// a "// Code generated ... DO NOT EDIT." comment before its package clause,
+// following https://golang.org/s/generatedcode.
+func isGenerated(f *ast.File) bool {
+	for _, cg := range f.Comments {
+		if cg.Pos() > f.Package {
+			break
+		}
+		for _, c := range cg.List {
+			if strings.HasPrefix(c.Text, "// Code generated ") && strings.HasSuffix(c.Text, " DO NOT EDIT.") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // IsSink determines whether a function is a sink.
 func (c Config) IsSink(path, recv, name string) bool {
+	if matchAnyFunction(c.RemovedSinks, path, recv, name) {
+		return false
+	}
 	for _, sink := range c.Sinks {
 		if sink.MatchFunction(path, recv, name) {
 			return true
@@ -106,8 +347,44 @@ func (c Config) IsSink(path, recv, name string) bool {
 	return false
 }
 
+// SinkArgument returns the position of the only argument that is considered
+// when checking a call to a sink, not counting the receiver. If the first
+// sink matching the function does not restrict its arguments, ok is false.
+func (c Config) SinkArgument(path, recv, name string) (arg int, ok bool) {
+	if matchAnyFunction(c.RemovedSinks, path, recv, name) {
+		return 0, false
+	}
+	for _, sink := range c.Sinks {
+		if !sink.MatchFunction(path, recv, name) {
+			continue
+		}
+		if sink.Argument == nil {
+			return 0, false
+		}
+		return *sink.Argument, true
+	}
+	return 0, false
+}
+
+// IsSinkWithSensitiveReceiver determines whether a method is a sink for which
+// a receiver carrying tainted arguments attached by a builder should be reported.
+func (c Config) IsSinkWithSensitiveReceiver(path, recv, name string) bool {
+	if matchAnyFunction(c.RemovedSinks, path, recv, name) {
+		return false
+	}
+	for _, sink := range c.Sinks {
+		if sink.SensitiveReceiver && sink.MatchFunction(path, recv, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsSanitizer determines whether a function is a sanitizer.
 func (c Config) IsSanitizer(path, recv, name string) bool {
+	if matchAnyFunction(c.RemovedSanitizers, path, recv, name) {
+		return false
+	}
 	for _, san := range c.Sanitizers {
 		if san.MatchFunction(path, recv, name) {
 			return true
@@ -116,8 +393,33 @@ func (c Config) IsSanitizer(path, recv, name string) bool {
 	return false
 }
 
+// Summary returns the configured summary of a function, if any.
+func (c Config) Summary(path, recv, name string) (FuncSummary, bool) {
+	for _, sm := range c.Summaries {
+		if sm.MatchFunction(path, recv, name) {
+			return sm.FuncSummary, true
+		}
+	}
+	return FuncSummary{}, false
+}
+
+func matchAnyFunction(matchers []funcMatcher, path, recv, name string) bool {
+	for _, fm := range matchers {
+		if fm.MatchFunction(path, recv, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsSourceType determines whether a type is a source.
 func (c Config) IsSourceType(path, name string) bool {
+	// Removing some of a type's fields does not remove the type.
+	for _, removed := range c.RemovedSources {
+		if _, allFields := removed.Field.(vacuousMatcher); allFields && removed.MatchType(path, name) {
+			return false
+		}
+	}
 	for _, source := range c.Sources {
 		if source.MatchType(path, name) {
 			return true
@@ -128,6 +430,11 @@ func (c Config) IsSourceType(path, name string) bool {
 
 // IsSourceField determines whether a field is a source.
 func (c Config) IsSourceField(path, typeName, fieldName string) bool {
+	for _, removed := range c.RemovedSources {
+		if removed.MatchField(path, typeName, fieldName) {
+			return false
+		}
+	}
 	for _, source := range c.Sources {
 		if source.MatchField(path, typeName, fieldName) {
 			return true
@@ -136,6 +443,128 @@ func (c Config) IsSourceField(path, typeName, fieldName string) bool {
 	return false
 }
 
+// IsSourceCall determines whether the value returned by a call to a function is a source.
+// constArgs contains the values of the call's constant arguments, indexed by their
+// position. If the function is a method, the receiver is not included.
+func (c Config) IsSourceCall(path, recv, name string, constArgs map[int]string) bool {
+	for _, sf := range c.SourceFunctions {
+		if sf.MatchCall(path, recv, name, constArgs) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSourceLookup determines whether the value obtained by looking up
+// a constant key in a map of the given type is a source.
+func (c Config) IsSourceLookup(path, typeName, key string) bool {
+	for _, ml := range c.SourceMapLookups {
+		if ml.MatchLookup(path, typeName, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSourceParameter determines whether a function's parameter is a source.
+// The parameter is identified by its position, not counting any receiver, and by its name.
+// signature is the function's signature without parameter names or receiver,
+// e.g. "func(net/http.ResponseWriter, *net/http.Request)".
+func (c Config) IsSourceParameter(path, recv, name, signature string, index int, paramName string) bool {
+	for _, pm := range c.SourceParameters {
+		if pm.MatchParameter(path, recv, name, signature, index, paramName) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSourceVariable determines whether loading a package-level variable produces a source.
+func (c Config) IsSourceVariable(path, name string) bool {
+	for _, vm := range c.SourceVariables {
+		if vm.MatchVariable(path, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ImplementsSourceInterface determines whether a Named Type, or a pointer to it,
+// implements one of the SourceInterfaces.
+// A named interface is resolved from the package being analyzed, see ForPackage,
+// or from the package that declares the type if no package is being analyzed.
+// The interface need not be imported by the package that declares the type.
+func (c Config) ImplementsSourceInterface(named *types.Named) bool {
+	if len(c.SourceInterfaces) == 0 || named.Obj().Pkg() == nil {
+		return false
+	}
+	// The interfaces are resolved once per type, since source types are looked up frequently.
+	if implements, ok := c.implements[named]; ok {
+		return implements
+	}
+	from := c.pkg
+	if from == nil {
+		from = named.Obj().Pkg()
+	}
+
+	// The method set of *T includes the methods of T. Pointers to interfaces have no methods.
+	var withMethods types.Type = types.NewPointer(named)
+	if types.IsInterface(named) {
+		withMethods = named
+	}
+	implements := false
+	for _, im := range c.SourceInterfaces {
+		if len(im.Methods) > 0 {
+			implements = hasMethods(withMethods, named.Obj().Pkg(), im.Methods)
+		} else if iface := lookupInterface(from, im.Package, im.Interface); iface != nil {
+			implements = types.Implements(withMethods, iface)
+		}
+		if implements {
+			break
+		}
+	}
+	if c.implements != nil {
+		c.implements[named] = implements
+	}
+	return implements
+}
+
+// hasMethods determines whether the method set of a type contains methods with the given names.
+func hasMethods(t types.Type, pkg *types.Package, names []string) bool {
+	mset := types.NewMethodSet(t)
+	for _, name := range names {
+		if mset.Lookup(pkg, name) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupInterface finds a named interface among a package and its transitive imports.
+func lookupInterface(pkg *types.Package, path, name string) *types.Interface {
+	seen := map[*types.Package]bool{pkg: true}
+	queue := []*types.Package{pkg}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if p.Path() == path {
+			obj, ok := p.Scope().Lookup(name).(*types.TypeName)
+			if !ok {
+				return nil
+			}
+			iface, _ := obj.Type().Underlying().(*types.Interface)
+			return iface
+		}
+		for _, imp := range p.Imports() {
+			if !seen[imp] {
+				seen[imp] = true
+				queue = append(queue, imp)
+			}
+		}
+	}
+	return nil
+}
+
 type stringMatcher interface {
 	MatchString(string) bool
 }
@@ -152,19 +581,23 @@ func (vacuousMatcher) MatchString(string) bool {
 	return true
 }
 
+// A fieldTagMatcher matches struct field tags by key and value,
+// e.g. `datapolicy:"pii"`. Each comma-separated value of a tag is matched separately.
 type fieldTagMatcher struct {
-	Key   string
-	Value string
+	Key   stringMatcher
+	Value stringMatcher
 }
 
 // this type uses the default unmarshaller and mirrors configuration key-value pairs
 type rawFieldTagMatcher struct {
-	Key   string
-	Value string
+	Key     *literalMatcher
+	KeyRE   *regexp.Regexp
+	Value   *literalMatcher
+	ValueRE *regexp.Regexp
 }
 
 func (ft *fieldTagMatcher) UnmarshalJSON(bytes []byte) error {
-	validFieldTagMatcherFields := []string{"key", "value"}
+	validFieldTagMatcherFields := []string{"key", "keyRE", "value", "valueRE"}
 	if err := validateFieldNames(&bytes, "fieldTagMatcher", validFieldTagMatcherFields); err != nil {
 		return err
 	}
@@ -174,19 +607,265 @@ func (ft *fieldTagMatcher) UnmarshalJSON(bytes []byte) error {
 		return err
 	}
 
-	if raw.Key == "" {
-		return fmt.Errorf("invalid field tag matcher: please provide a non-empty Key")
+	if raw.Key != nil && raw.KeyRE != nil {
+		return fmt.Errorf("expected only one of Key, KeyRE in config definition for a field tag matcher")
+	}
+	if raw.Value != nil && raw.ValueRE != nil {
+		return fmt.Errorf("expected only one of Value, ValueRE in config definition for a field tag matcher")
+	}
+	if (raw.Key == nil || *raw.Key == "") && raw.KeyRE == nil {
+		return fmt.Errorf("invalid field tag matcher: please provide a non-empty Key or a KeyRE")
 	}
-	if raw.Value == "" {
-		return fmt.Errorf("invalid field tag matcher: please provide a non-empty Value")
+	if (raw.Value == nil || *raw.Value == "") && raw.ValueRE == nil {
+		return fmt.Errorf("invalid field tag matcher: please provide a non-empty Value or a ValueRE")
 	}
 
-	ft.Key = raw.Key
-	ft.Value = raw.Value
+	ft.Key = matcherFrom(raw.Key, raw.KeyRE)
+	ft.Value = matcherFrom(raw.Value, raw.ValueRE)
 
 	return nil
 }
 
+// MatchTag determines whether one of the key-value pairs of a field tag is matched.
+func (ft fieldTagMatcher) MatchTag(pairs []tagPair) bool {
+	for _, p := range pairs {
+		if !ft.Key.MatchString(p.key) {
+			continue
+		}
+		for _, v := range strings.Split(p.value, ",") {
+			if ft.Value.MatchString(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// A protoOptionMatcher identifies a custom protobuf field option, e.g. (privacy.sensitive),
+// by its full Name. The fields of generated messages for which the option is set are Sources.
+// The option's field Number is resolved from the extension's declaration in the analyzed
+// packages, unless it is provided.
+type protoOptionMatcher struct {
+	Name   string
+	Number int32
+}
+
+// this type uses the default unmarshaller and mirrors configuration key-value pairs
+type rawProtoOptionMatcher struct {
+	Name   string
+	Number int32
+}
+
+func (pm *protoOptionMatcher) UnmarshalJSON(bytes []byte) error {
+	validProtoOptionMatcherFields := []string{"name", "number"}
+	if err := validateFieldNames(&bytes, "protoOptionMatcher", validProtoOptionMatcherFields); err != nil {
+		return err
+	}
+
+	raw := rawProtoOptionMatcher{}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+
+	if raw.Name == "" {
+		return fmt.Errorf("invalid proto option matcher: please provide the option's full Name, e.g. privacy.sensitive")
+	}
+	if raw.Number < 0 {
+		return fmt.Errorf("invalid proto option matcher: Number must not be negative")
+	}
+
+	*pm = protoOptionMatcher(raw)
+	return nil
+}
+
+// A sourceHeuristic scores struct fields that look sensitive, in order to suggest Sources.
+// A field matches if its name matches FieldRE, its type, written with full package paths,
+// matches TypeRE, and its tag matches Tag. At least one of them must be provided.
+// Scores may be negative, e.g. to discount fields named like "TokenExpiry".
+type sourceHeuristic struct {
+	FieldRE *regexp.Regexp
+	TypeRE  *regexp.Regexp
+	Tag     *fieldTagMatcher
+	Score   int
+}
+
+// this type uses the default unmarshaller and mirrors configuration key-value pairs
+type rawSourceHeuristic struct {
+	FieldRE *regexp.Regexp
+	TypeRE  *regexp.Regexp
+	Tag     *fieldTagMatcher
+	Score   int
+}
+
+func (sh *sourceHeuristic) UnmarshalJSON(bytes []byte) error {
+	validSourceHeuristicFields := []string{"fieldRE", "typeRE", "tag", "score"}
+	if err := validateFieldNames(&bytes, "sourceHeuristic", validSourceHeuristicFields); err != nil {
+		return err
+	}
+
+	raw := rawSourceHeuristic{}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+
+	if raw.FieldRE == nil && raw.TypeRE == nil && raw.Tag == nil {
+		return fmt.Errorf("invalid source heuristic: please provide at least one of FieldRE, TypeRE, Tag")
+	}
+	if raw.Score == 0 {
+		return fmt.Errorf("invalid source heuristic: please provide a non-zero Score")
+	}
+
+	*sh = sourceHeuristic(raw)
+	return nil
+}
+
+// match determines whether a field matches the heuristic. If the heuristic matches tags,
+// the key-value pairs of the field's tag that it matches are returned.
+func (sh sourceHeuristic) match(fieldName, fieldType string, pairs []tagPair) (bool, []FieldTag) {
+	if sh.FieldRE != nil && !sh.FieldRE.MatchString(fieldName) {
+		return false, nil
+	}
+	if sh.TypeRE != nil && !sh.TypeRE.MatchString(fieldType) {
+		return false, nil
+	}
+	if sh.Tag == nil {
+		return true, nil
+	}
+	var matched []FieldTag
+	for _, p := range pairs {
+		if !sh.Tag.Key.MatchString(p.key) {
+			continue
+		}
+		for _, v := range strings.Split(p.value, ",") {
+			if sh.Tag.Value.MatchString(v) {
+				matched = append(matched, FieldTag{Key: p.key, Value: v})
+			}
+		}
+	}
+	return len(matched) > 0, matched
+}
+
+// A FieldTag is a key and one of the values of a struct field tag, e.g. datapolicy and pii
+// in `datapolicy:"pii,internal"`.
+type FieldTag struct {
+	Key, Value string
+}
+
+// defaultSourceHeuristics are used when no SourceHeuristics are configured.
+// Fields are suggested if they are named like credentials or personal data,
+// have the type of a private key or are tagged as sensitive, unless their name
+// or type shows that they only describe sensitive data, e.g. "SecretName" or "HasPassword".
+var defaultSourceHeuristics = mustParseSourceHeuristics(`
+- FieldRE: "(?i)(passw(or)?d|passphrase|secret|token|credential|private_?key|api_?key|access_?key|signing_?key|cookie)"
+  Score: 2
+- FieldRE: "(?i)(ssn|social_?security|e_?mail|phone|birth|salary|iban|card_?number|cvv)"
+  Score: 2
+- TypeRE: "(crypto/(rsa|ecdsa|ed25519)\\.PrivateKey|golang\\.org/x/oauth2\\.Token)$"
+  Score: 2
+- Tag:
+    KeyRE: "."
+    ValueRE: "(?i)^(secret|sensitive|pii|credential|confidential)"
+  Score: 2
+- FieldRE: "^(is|has|num|Is|Has|Num)[A-Z_]|(Id|ID|_id)$|(?i)(public\\w*|type|kind|len(gth)?|count|size|expir\\w*|url|uri|endpoint|path|file|name|enabled|required|policy|header)$"
+  Score: -2
+- TypeRE: "^(bool|u?int(8|16|32|64)?|float(32|64)|time\\.(Time|Duration)|func\\(.*|chan .*)$"
+  Score: -2
+`)
+
+func mustParseSourceHeuristics(s string) []sourceHeuristic {
+	var heuristics []sourceHeuristic
+	if err := yaml.UnmarshalStrict([]byte(s), &heuristics); err != nil {
+		panic(fmt.Sprintf("invalid default source heuristics: %v", err))
+	}
+	return heuristics
+}
+
+// defaultSourceSuggestionThreshold is used when no SourceSuggestionThreshold is configured.
+const defaultSourceSuggestionThreshold = 2
+
+// ScoreSourceField scores a struct field according to the SourceHeuristics, or to the default
+// heuristics if none are configured. The key-value pairs of the field's tag that matched
+// a heuristic are also returned, since they can be suggested as FieldTags.
+func (c Config) ScoreSourceField(fieldName, fieldType, tag string) (score int, tags []FieldTag) {
+	heuristics := c.SourceHeuristics
+	if len(heuristics) == 0 {
+		heuristics = defaultSourceHeuristics
+	}
+	pairs := parseTag(tag)
+	for _, sh := range heuristics {
+		if ok, matched := sh.match(fieldName, fieldType, pairs); ok {
+			score += sh.Score
+			tags = append(tags, matched...)
+		}
+	}
+	return score, tags
+}
+
+// IsSuggestedSourceScore determines whether a field with the given score looks sensitive enough
+// to be suggested as a Source.
+func (c Config) IsSuggestedSourceScore(score int) bool {
+	threshold := c.SourceSuggestionThreshold
+	if threshold == 0 {
+		threshold = defaultSourceSuggestionThreshold
+	}
+	return score >= threshold
+}
+
+// A tagPair is a key-value pair of a struct field tag.
+type tagPair struct {
+	key, value string
+}
+
+// parseTag returns the key-value pairs of a struct field tag, which may be quoted.
+// Parsing follows the conventions of reflect.StructTag, and stops at the first malformed pair.
+func parseTag(tag string) []tagPair {
+	if unq, err := strconv.Unquote(tag); err == nil {
+		tag = unq
+	}
+	var pairs []tagPair
+	for tag != "" {
+		// Skip leading space.
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		// Scan to colon. A space, a quote or a control character is a syntax error.
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		// Scan quoted string to find value.
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			break
+		}
+		tag = tag[i+1:]
+		pairs = append(pairs, tagPair{key: key, value: value})
+	}
+	return pairs
+}
+
 // Returns the first non-nil matcher.
 // If all are nil, returns a vacuousMatcher.
 func matcherFrom(lm *literalMatcher, r *regexp.Regexp) stringMatcher {
@@ -274,8 +953,14 @@ type rawFuncMatcher struct {
 }
 
 func (fm *funcMatcher) UnmarshalJSON(bytes []byte) error {
-	validFuncMatcherFields := []string{"package", "packageRE", "receiver", "receiverRE", "method", "methodRE"}
-	if err := validateFieldNames(&bytes, "funcMatcher", validFuncMatcherFields); err != nil {
+	return fm.unmarshal(bytes, "funcMatcher", nil)
+}
+
+// unmarshal unmarshals a funcMatcher from a matcher that may have additional fields,
+// e.g. a sinkMatcher.
+func (fm *funcMatcher) unmarshal(bytes []byte, matcherType string, extraFields []string) error {
+	validFuncMatcherFields := append([]string{"package", "packageRE", "receiver", "receiverRE", "method", "methodRE"}, extraFields...)
+	if err := validateFieldNames(&bytes, matcherType, validFuncMatcherFields); err != nil {
 		return err
 	}
 
@@ -307,6 +992,349 @@ func (fm funcMatcher) MatchFunction(path, receiver, name string) bool {
 	return fm.Package.MatchString(path) && fm.Receiver.MatchString(receiver) && fm.Method.MatchString(name)
 }
 
+// A sinkMatcher matches sinks in the same way as a funcMatcher.
+// By default, a call to a sink is reported if one of its arguments or its receiver is tainted,
+// unless the receiver is only tainted because tainted arguments were attached to it by a builder method,
+// e.g. logger.With("key", value). If SensitiveReceiver is set, such receivers are also considered.
+type sinkMatcher struct {
+	funcMatcher
+	SensitiveReceiver bool
+	// If Argument is provided, only the argument at that position is considered,
+	// e.g. the query of a database/sql call but not its parameters.
+	// Arguments are numbered from 0, not counting the receiver.
+	Argument *int
+}
+
+// this type uses the default unmarshaller and mirrors configuration key-value pairs
+type rawSinkMatcher struct {
+	SensitiveReceiver bool
+	Argument          *int
+}
+
+func (sm *sinkMatcher) UnmarshalJSON(bytes []byte) error {
+	if err := sm.funcMatcher.unmarshal(bytes, "sinkMatcher", []string{"sensitiveReceiver", "argument"}); err != nil {
+		return err
+	}
+
+	raw := rawSinkMatcher{}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+	if raw.Argument != nil && *raw.Argument < 0 {
+		return fmt.Errorf("invalid sink matcher: Argument must not be negative")
+	}
+	sm.SensitiveReceiver = raw.SensitiveReceiver
+	sm.Argument = raw.Argument
+	return nil
+}
+
+// A FuncSummary describes how taint propagates through calls to a function:
+// if one of the IfTainted arguments is tainted, then the TaintedArgs arguments
+// and the TaintedRets return values become tainted.
+// Arguments are numbered from 0, counting the receiver of a method as the first argument.
+type FuncSummary struct {
+	IfTainted   []int
+	TaintedArgs []int
+	TaintedRets []int
+}
+
+// A summaryMatcher matches functions in the same way as a funcMatcher.
+// Calls to matched functions propagate taint according to the summary,
+// e.g. for third-party libraries that are not analyzed.
+type summaryMatcher struct {
+	funcMatcher
+	FuncSummary
+}
+
+func (sm *summaryMatcher) UnmarshalJSON(bytes []byte) error {
+	if err := sm.funcMatcher.unmarshal(bytes, "summaryMatcher", []string{"ifTainted", "taintedArgs", "taintedRets"}); err != nil {
+		return err
+	}
+
+	raw := FuncSummary{}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+	if len(raw.IfTainted) == 0 {
+		return fmt.Errorf("invalid summary: please provide the IfTainted arguments")
+	}
+	for _, positions := range [][]int{raw.IfTainted, raw.TaintedArgs, raw.TaintedRets} {
+		for _, p := range positions {
+			if p < 0 {
+				return fmt.Errorf("invalid summary: positions must not be negative, got %d", p)
+			}
+		}
+	}
+	sm.FuncSummary = raw
+	return nil
+}
+
+// A sourceFuncMatcher matches functions in the same way as a funcMatcher.
+// The values returned by calls to matched functions are sources.
+// If ArgumentRE is provided, only calls with a constant argument matching
+// ArgumentRE are sources, e.g. os.Getenv("DB_PASSWORD").
+// If Argument is also provided, only the argument at that position is considered.
+// Arguments are numbered from 0, not counting the receiver.
+type sourceFuncMatcher struct {
+	funcMatcher
+	Argument   *int
+	ArgumentRE *regexp.Regexp
+}
+
+// this type uses the default unmarshaller and mirrors configuration key-value pairs
+type rawSourceFuncMatcher struct {
+	Argument   *int
+	ArgumentRE *regexp.Regexp
+}
+
+func (sf *sourceFuncMatcher) UnmarshalJSON(bytes []byte) error {
+	if err := sf.funcMatcher.unmarshal(bytes, "sourceFuncMatcher", []string{"argument", "argumentRE"}); err != nil {
+		return err
+	}
+
+	raw := rawSourceFuncMatcher{}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+
+	if raw.Argument != nil && *raw.Argument < 0 {
+		return fmt.Errorf("invalid source function matcher: Argument must not be negative")
+	}
+	if raw.Argument != nil && raw.ArgumentRE == nil {
+		return fmt.Errorf("invalid source function matcher: Argument requires ArgumentRE")
+	}
+
+	sf.Argument = raw.Argument
+	sf.ArgumentRE = raw.ArgumentRE
+	return nil
+}
+
+func (sf sourceFuncMatcher) MatchCall(path, receiver, name string, constArgs map[int]string) bool {
+	if !sf.MatchFunction(path, receiver, name) {
+		return false
+	}
+	if sf.ArgumentRE == nil {
+		return true
+	}
+	for i, arg := range constArgs {
+		if sf.Argument != nil && *sf.Argument != i {
+			continue
+		}
+		if sf.ArgumentRE.MatchString(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// A mapLookupMatcher matches lookups of constant keys in maps, e.g. header["Cookie"].
+// The map's type is matched by package and type name, as for a sourceMatcher,
+// and the key is matched against KeyRE.
+type mapLookupMatcher struct {
+	Package stringMatcher
+	Type    stringMatcher
+	Key     stringMatcher
+}
+
+// this type uses the default unmarshaller and mirrors configuration key-value pairs
+type rawMapLookupMatcher struct {
+	Package   *literalMatcher
+	Type      *literalMatcher
+	PackageRE *regexp.Regexp
+	TypeRE    *regexp.Regexp
+	KeyRE     *regexp.Regexp
+}
+
+func (ml *mapLookupMatcher) UnmarshalJSON(bytes []byte) error {
+	validMapLookupMatcherFields := []string{"package", "packageRE", "type", "typeRE", "keyRE"}
+	if err := validateFieldNames(&bytes, "mapLookupMatcher", validMapLookupMatcherFields); err != nil {
+		return err
+	}
+
+	raw := rawMapLookupMatcher{}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+
+	// Only one of literal and regexp field can be specified.
+	if raw.Package != nil && raw.PackageRE != nil {
+		return fmt.Errorf("expected only one of Package, PackageRE in config definition for a map lookup matcher")
+	}
+	if raw.Type != nil && raw.TypeRE != nil {
+		return fmt.Errorf("expected only one of Type, TypeRE in config definition for a map lookup matcher")
+	}
+	if raw.KeyRE == nil {
+		return fmt.Errorf("invalid map lookup matcher: please provide a KeyRE")
+	}
+
+	*ml = mapLookupMatcher{
+		Package: matcherFrom(raw.Package, raw.PackageRE),
+		Type:    matcherFrom(raw.Type, raw.TypeRE),
+		Key:     raw.KeyRE,
+	}
+	return nil
+}
+
+func (ml mapLookupMatcher) MatchLookup(path, typeName, key string) bool {
+	return ml.Package.MatchString(path) && ml.Type.MatchString(typeName) && ml.Key.MatchString(key)
+}
+
+// A variableMatcher matches package-level variables, e.g. os.Args,
+// by package and variable name.
+type variableMatcher struct {
+	Package stringMatcher
+	Name    stringMatcher
+}
+
+// this type uses the default unmarshaller and mirrors configuration key-value pairs
+type rawVariableMatcher struct {
+	Package   *literalMatcher
+	Name      *literalMatcher
+	PackageRE *regexp.Regexp
+	NameRE    *regexp.Regexp
+}
+
+func (vm *variableMatcher) UnmarshalJSON(bytes []byte) error {
+	validVariableMatcherFields := []string{"package", "packageRE", "name", "nameRE"}
+	if err := validateFieldNames(&bytes, "variableMatcher", validVariableMatcherFields); err != nil {
+		return err
+	}
+
+	raw := rawVariableMatcher{}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+
+	// Only one of literal and regexp field can be specified.
+	if raw.Package != nil && raw.PackageRE != nil {
+		return fmt.Errorf("expected only one of Package, PackageRE in config definition for a variable matcher")
+	}
+	if raw.Name != nil && raw.NameRE != nil {
+		return fmt.Errorf("expected only one of Name, NameRE in config definition for a variable matcher")
+	}
+	if raw.Name == nil && raw.NameRE == nil {
+		return fmt.Errorf("invalid variable matcher: please provide one of Name, NameRE")
+	}
+
+	*vm = variableMatcher{
+		Package: matcherFrom(raw.Package, raw.PackageRE),
+		Name:    matcherFrom(raw.Name, raw.NameRE),
+	}
+	return nil
+}
+
+func (vm variableMatcher) MatchVariable(path, name string) bool {
+	return vm.Package.MatchString(path) && vm.Name.MatchString(name)
+}
+
+// An interfaceMatcher identifies Source types by an interface that they implement,
+// e.g. a marker interface. The interface is either a named interface, identified
+// by its Package and Interface name, or the set of methods with the given names.
+type interfaceMatcher struct {
+	Package   string
+	Interface string
+	Methods   []string
+}
+
+// this type uses the default unmarshaller and mirrors configuration key-value pairs
+type rawInterfaceMatcher struct {
+	Package   string
+	Interface string
+	Methods   []string
+}
+
+func (im *interfaceMatcher) UnmarshalJSON(bytes []byte) error {
+	validInterfaceMatcherFields := []string{"package", "interface", "methods"}
+	if err := validateFieldNames(&bytes, "interfaceMatcher", validInterfaceMatcherFields); err != nil {
+		return err
+	}
+
+	raw := rawInterfaceMatcher{}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+
+	named := raw.Package != "" || raw.Interface != ""
+	switch {
+	case named && len(raw.Methods) > 0:
+		return fmt.Errorf("expected only one of Package and Interface, or Methods in config definition for an interface matcher")
+	case named && (raw.Package == "" || raw.Interface == ""):
+		return fmt.Errorf("invalid interface matcher: please provide both a Package and an Interface")
+	case !named && len(raw.Methods) == 0:
+		return fmt.Errorf("invalid interface matcher: please provide a Package and an Interface, or Methods")
+	}
+	for _, m := range raw.Methods {
+		if m == "" {
+			return fmt.Errorf("invalid interface matcher: method names cannot be empty")
+		}
+	}
+
+	*im = interfaceMatcher(raw)
+	return nil
+}
+
+// A paramMatcher matches parameters of functions, e.g. the request parameter of HTTP handlers.
+// Functions are matched in the same way as for a funcMatcher, and may also be matched by
+// Signature, written without parameter names or receiver, e.g.
+// "func(net/http.ResponseWriter, *net/http.Request)".
+// Parameters are matched by position (Parameter, counting from 0 and not counting the receiver)
+// and by name (ParameterName, ParameterNameRE). If neither is provided, all parameters match.
+type paramMatcher struct {
+	funcMatcher
+	Signature     *string
+	Parameter     *int
+	ParameterName stringMatcher
+}
+
+// this type uses the default unmarshaller and mirrors configuration key-value pairs
+type rawParamMatcher struct {
+	Signature       *string
+	Parameter       *int
+	ParameterName   *literalMatcher
+	ParameterNameRE *regexp.Regexp
+}
+
+func (pm *paramMatcher) UnmarshalJSON(bytes []byte) error {
+	extraFields := []string{"signature", "parameter", "parameterName", "parameterNameRE"}
+	if err := pm.funcMatcher.unmarshal(bytes, "paramMatcher", extraFields); err != nil {
+		return err
+	}
+
+	raw := rawParamMatcher{}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+
+	if raw.ParameterName != nil && raw.ParameterNameRE != nil {
+		return fmt.Errorf("expected only one of ParameterName, ParameterNameRE in config definition for a parameter matcher")
+	}
+	if raw.Parameter != nil && *raw.Parameter < 0 {
+		return fmt.Errorf("invalid parameter matcher: Parameter must not be negative")
+	}
+	// Without a method or a signature, every function would match.
+	if _, vacuous := pm.Method.(vacuousMatcher); vacuous && raw.Signature == nil {
+		return fmt.Errorf("invalid parameter matcher: please provide one of Method, MethodRE, Signature")
+	}
+
+	pm.Signature = raw.Signature
+	pm.Parameter = raw.Parameter
+	pm.ParameterName = matcherFrom(raw.ParameterName, raw.ParameterNameRE)
+	return nil
+}
+
+func (pm paramMatcher) MatchParameter(path, receiver, name, signature string, index int, paramName string) bool {
+	if !pm.MatchFunction(path, receiver, name) {
+		return false
+	}
+	if pm.Signature != nil && *pm.Signature != signature {
+		return false
+	}
+	if pm.Parameter != nil && *pm.Parameter != index {
+		return false
+	}
+	return pm.ParameterName.MatchString(paramName)
+}
+
 // ReadConfig reads configuration from the config cache.
 // The cache reads, parses, and validates the config file if necessary.
 // If the config bytes were set using SetConfigBytes, they are used instead.
@@ -318,10 +1346,98 @@ func ReadConfig() (*Config, error) {
 	return cache.read(configFile)
 }
 
+// Parse parses and validates configuration provided as YAML.
+// This is useful for analyzers that use a built-in configuration.
+func Parse(b []byte) (*Config, error) {
+	c := new(Config)
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	if err := c.validateOverrides(); err != nil {
+		return nil, err
+	}
+	if err := c.validateAllow(); err != nil {
+		return nil, err
+	}
+	if err := c.validateExcludeFiles(); err != nil {
+		return nil, err
+	}
+	if err := c.validateProfiles(); err != nil {
+		return nil, err
+	}
+	for _, p := range c.Profiles {
+		p.Summaries = c.Summaries
+	}
+	return c, nil
+}
+
+// validateAllow ensures that each Allow rule has a source, a sink and a reason.
+func (c *Config) validateAllow() error {
+	for i, ar := range c.Allow {
+		if ar.Source == nil || ar.Sink == nil {
+			return fmt.Errorf("invalid Allow rule %d: please provide a Source and a Sink", i)
+		}
+		if strings.TrimSpace(ar.Reason) == "" {
+			return fmt.Errorf("invalid Allow rule %d: please provide a Reason", i)
+		}
+	}
+	return nil
+}
+
+// validateExcludeFiles ensures that the globs used to exclude files are well-formed.
+func (c *Config) validateExcludeFiles() error {
+	for _, pattern := range c.ExcludeFiles {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid ExcludeFiles pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// validateOverrides ensures that each override specifies the packages it applies to.
+func (c *Config) validateOverrides() error {
+	for _, o := range c.Overrides {
+		if o.PackageRE == nil {
+			return fmt.Errorf("invalid override: please provide a PackageRE")
+		}
+	}
+	return nil
+}
+
+// validateProfiles ensures that profiles are uniquely named, are not nested,
+// and do not configure settings that are shared with the top-level Config.
+func (c *Config) validateProfiles() error {
+	seen := map[string]bool{}
+	for _, p := range c.Profiles {
+		switch {
+		case p == nil || p.Name == "":
+			return fmt.Errorf("invalid profile: please provide a Name")
+		case seen[p.Name]:
+			return fmt.Errorf("invalid profile %q: profile names must be unique", p.Name)
+		case len(p.Profiles) > 0:
+			return fmt.Errorf("invalid profile %q: profiles cannot be nested", p.Name)
+		case len(p.FieldTags) > 0 || len(p.SafeFieldTags) > 0 || len(p.ProtoFieldOptions) > 0 || len(p.Summaries) > 0:
+			return fmt.Errorf("invalid profile %q: FieldTags, SafeFieldTags, ProtoFieldOptions and Summaries are shared by all profiles and must be configured at the top level", p.Name)
+		case p.AllowRedactedFormatting:
+			return fmt.Errorf("invalid profile %q: AllowRedactedFormatting is only supported at the top level", p.Name)
+		}
+		if err := p.validateOverrides(); err != nil {
+			return fmt.Errorf("invalid profile %q: %v", p.Name, err)
+		}
+		if err := p.validateAllow(); err != nil {
+			return fmt.Errorf("invalid profile %q: %v", p.Name, err)
+		}
+		if err := p.validateExcludeFiles(); err != nil {
+			return fmt.Errorf("invalid profile %q: %v", p.Name, err)
+		}
+		seen[p.Name] = true
+	}
+	return nil
+}
+
 func readConfigBytes() (*Config, error) {
 	configBytesOnce.Do(func() {
-		configFromBytes = new(Config)
-		configFromBytesErr = yaml.UnmarshalStrict(configBytes, configFromBytes)
+		configFromBytes, configFromBytesErr = Parse(configBytes)
 		if configFromBytesErr != nil {
 			fmt.Println(configFromBytesErr)
 		}
@@ -340,7 +1456,6 @@ type configCacheElement struct {
 
 func (r *configCacheElement) readOnce() (*Config, error) {
 	r.once.Do(func() {
-		c := new(Config)
 		bytes, err := ioutil.ReadFile(r.sourceFile)
 		if err != nil {
 			fmt.Println(err)
@@ -348,7 +1463,8 @@ func (r *configCacheElement) readOnce() (*Config, error) {
 			return
 		}
 
-		if err := yaml.UnmarshalStrict(bytes, c); err != nil {
+		c, err := Parse(bytes)
+		if err != nil {
 			fmt.Println(err)
 			r.err = err
 			return
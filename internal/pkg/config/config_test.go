@@ -40,6 +40,10 @@ func runTest(pass *analysis.Pass) (interface{}, error) {
 		return nil, err
 	}
 
+	if conf.IsSourceHandlingPackage(pass.Pkg.Path()) {
+		pass.Reportf(pass.Files[0].Package, "source handling package")
+	}
+
 	for _, f := range in.SrcFuncs {
 		if conf.IsSink(utils.DecomposeFunction(f)) {
 			pass.Reportf(f.Pos(), "sink")
@@ -0,0 +1,89 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestIsExcludedFile(t *testing.T) {
+	conf, err := Parse([]byte(`
+ExcludeFiles:
+- "zz_generated.*.go"
+- "*.pb.go"
+- "internal/mock/*.go"
+ExcludeGenerated: true
+ExcludeTests: true
+`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing exclusions: %v", err)
+	}
+
+	tests := []struct {
+		filename  string
+		generated bool
+		want      bool
+	}{
+		{filename: "/src/example.com/api/zz_generated.deepcopy.go", want: true},
+		{filename: "/src/example.com/api/api.pb.go", want: true},
+		{filename: "/src/example.com/internal/mock/client.go", want: true},
+		{filename: "/src/example.com/internal/mock/sub/client.go", want: false},
+		{filename: "/src/example.com/mock/client.go", want: false},
+		{filename: "/src/example.com/api/api_test.go", want: true},
+		{filename: "/src/example.com/api/api.go", generated: true, want: true},
+		{filename: "/src/example.com/api/api.go", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := conf.IsExcludedFile(tt.filename, tt.generated); got != tt.want {
+			t.Errorf("IsExcludedFile(%q, %v) == %v, want %v", tt.filename, tt.generated, got, tt.want)
+		}
+	}
+
+	if new(Config).IsExcludedFile("/src/example.com/api/api_test.go", true) {
+		t.Error("files should not be excluded by default")
+	}
+}
+
+func TestExcludedFiles(t *testing.T) {
+	sources := map[string]string{
+		"generated.go": "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n",
+		"doc.go":       "// Package api is not generated.\npackage api\n\n// Code generated by hand. DO NOT EDIT.\nvar x int\n",
+	}
+	fset := token.NewFileSet()
+	want := map[string]bool{"generated.go": true, "doc.go": false}
+
+	for name, src := range sources {
+		f, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		excluded := Config{ExcludeGenerated: true}.ExcludedFiles(fset, []*ast.File{f})
+		if got := excluded[fset.File(f.Pos())]; got != want[name] {
+			t.Errorf("%s: got excluded = %v, want %v", name, got, want[name])
+		}
+	}
+}
+
+func TestExcludeFilesInvalidPattern(t *testing.T) {
+	if _, err := Parse([]byte(`
+ExcludeFiles:
+- "[.go"`)); err == nil {
+		t.Error("got err = nil when parsing a malformed ExcludeFiles pattern, want error")
+	}
+}
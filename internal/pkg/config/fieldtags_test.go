@@ -99,3 +99,81 @@ func TestFieldTagsIdentification(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldTagsRegexpMatching(t *testing.T) {
+	conf, err := Parse([]byte(`
+FieldTags:
+- KeyRE: "^(datapolicy|dp)$"
+  ValueRE: "^(pii|credential)"
+SafeFieldTags:
+- Key: datapolicy
+  Value: public
+`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing field tags: %v", err)
+	}
+
+	cases := []struct {
+		desc       string
+		tag        string
+		wantSource bool
+		wantSafe   bool
+	}{
+		{
+			desc:       "regexp key and value",
+			tag:        "`datapolicy:\"pii.email\"`",
+			wantSource: true,
+		},
+		{
+			desc:       "other key matching the regexp",
+			tag:        "`dp:\"credential\"`",
+			wantSource: true,
+		},
+		{
+			desc:       "one of multiple values",
+			tag:        "`json:\"phone\" datapolicy:\"location,pii.phone\"`",
+			wantSource: true,
+		},
+		{
+			desc: "value not matching the regexp",
+			tag:  "`datapolicy:\"nonpii\"`",
+		},
+		{
+			desc:       "built-in source tag",
+			tag:        "`levee:\"source\"`",
+			wantSource: true,
+		},
+		{
+			desc:     "built-in safe tag",
+			tag:      "`levee:\"safe\"`",
+			wantSafe: true,
+		},
+		{
+			desc:     "built-in omission tag",
+			tag:      "`levee:\"-\"`",
+			wantSafe: true,
+		},
+		{
+			desc:     "configured safe tag",
+			tag:      "`datapolicy:\"public\"`",
+			wantSafe: true,
+		},
+		{
+			desc:       "source and safe",
+			tag:        "`levee:\"source,safe\"`",
+			wantSource: true,
+			wantSafe:   true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := conf.IsSourceFieldTag(tt.tag); got != tt.wantSource {
+				t.Errorf("IsSourceFieldTag(%q) == %v, want %v", tt.tag, got, tt.wantSource)
+			}
+			if got := conf.IsSafeFieldTag(tt.tag); got != tt.wantSafe {
+				t.Errorf("IsSafeFieldTag(%q) == %v, want %v", tt.tag, got, tt.wantSafe)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+func TestDefaultSourceHeuristics(t *testing.T) {
+	conf := Config{}
+	cases := []struct {
+		field, fieldType, tag string
+		want                  bool
+	}{
+		{field: "Password", fieldType: "string", want: true},
+		{field: "ClientSecret", fieldType: "string", want: true},
+		{field: "Key", fieldType: "*crypto/rsa.PrivateKey", want: true},
+		{field: "Email", fieldType: "string", want: true},
+		{field: "Data", fieldType: "[]byte", tag: "`datapolicy:\"internal,sensitive\"`", want: true},
+		{field: "HasPassword", fieldType: "bool"},
+		{field: "SecretName", fieldType: "string"},
+		{field: "TokenExpiry", fieldType: "time.Time"},
+		{field: "UserID", fieldType: "string"},
+		{field: "Issuer", fieldType: "string"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.field, func(t *testing.T) {
+			score, _ := conf.ScoreSourceField(c.field, c.fieldType, c.tag)
+			if got := conf.IsSuggestedSourceScore(score); got != c.want {
+				t.Errorf("field %s %s with score %d suggested: %v, want %v", c.field, c.fieldType, score, got, c.want)
+			}
+		})
+	}
+}
+
+func TestConfiguredSourceHeuristics(t *testing.T) {
+	conf, err := Parse([]byte(`
+SourceHeuristics:
+- FieldRE: "(?i)badge"
+  Score: 1
+- Tag:
+    Key: audit
+    Value: restricted
+  Score: 2
+SourceSuggestionThreshold: 3`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing heuristics: %v", err)
+	}
+
+	if score, _ := conf.ScoreSourceField("Password", "string", ""); score != 0 {
+		t.Errorf("configured heuristics should replace the default ones, got score %d for Password", score)
+	}
+	score, tags := conf.ScoreSourceField("BadgeNumber", "string", "`audit:\"restricted\"`")
+	if score != 3 || !conf.IsSuggestedSourceScore(score) {
+		t.Errorf("got score %d for BadgeNumber, want 3", score)
+	}
+	if len(tags) != 1 || tags[0] != (FieldTag{Key: "audit", Value: "restricted"}) {
+		t.Errorf("got matched tags %v, want [{audit restricted}]", tags)
+	}
+}
@@ -147,6 +147,84 @@ Method: bar`,
 	}
 }
 
+func TestSinkMatcherUnmarshalling(t *testing.T) {
+	testCases := []struct {
+		desc, yaml            string
+		wantErr               bool
+		wantSensitiveReceiver bool
+	}{
+		{
+			desc: "Receiver is not sensitive by default",
+			yaml: `
+Package: foo
+Method: bar`,
+			wantSensitiveReceiver: false,
+		},
+		{
+			desc: "Receiver can be configured as sensitive",
+			yaml: `
+Package: foo
+Receiver: "*Logger"
+Method: bar
+SensitiveReceiver: true`,
+			wantSensitiveReceiver: true,
+		},
+		{
+			desc: "Unmarshaling is strict",
+			yaml: `
+Blahblah: foo
+SensitiveReceiver: true`,
+			wantErr: true,
+		},
+		{
+			desc: "Function matcher errors are reported",
+			yaml: `
+Package: foo
+PackageRE: bar
+SensitiveReceiver: true`,
+			wantErr: true,
+		},
+		{
+			desc: "Argument can be configured",
+			yaml: `
+Package: foo
+Method: bar
+Argument: 1`,
+		},
+		{
+			desc: "Argument must not be negative",
+			yaml: `
+Package: foo
+Method: bar
+Argument: -1`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sm := sinkMatcher{}
+			err := yaml.UnmarshalStrict([]byte(tc.yaml), &sm)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("got err = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error unmarshalling sinkMatcher: %v", err)
+			}
+			if sm.SensitiveReceiver != tc.wantSensitiveReceiver {
+				t.Errorf("got SensitiveReceiver = %v, want %v", sm.SensitiveReceiver, tc.wantSensitiveReceiver)
+			}
+			if !sm.MatchFunction("foo", "*Logger", "bar") {
+				t.Errorf("MatchFunction(%q, %q, %q) got false, want true", "foo", "*Logger", "bar")
+			}
+		})
+	}
+}
+
 func TestSourceMatcherUnmarshalingErrorCases(t *testing.T) {
 	testCases := []struct {
 		desc, yaml string
@@ -312,6 +390,34 @@ key: good
 val: "two letters short"`,
 			wantErr: true,
 		},
+		{
+			desc: "key and keyRE",
+			yaml: `
+key: foo
+keyRE: foo
+value: bar`,
+			wantErr: true,
+		},
+		{
+			desc: "value and valueRE",
+			yaml: `
+key: foo
+value: bar
+valueRE: bar`,
+			wantErr: true,
+		},
+		{
+			desc:    "empty key",
+			yaml:    `{key: "", value: bar}`,
+			wantErr: true,
+		},
+		{
+			desc: "valid field tag config with regexps",
+			yaml: `
+keyRE: "^(foo|bar)$"
+valueRE: "^baz"`,
+			wantErr: false,
+		},
 		{
 			desc: "valid field tag config, lowercase",
 			yaml: `
@@ -411,3 +517,472 @@ func TestMatcherTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestSourceFuncMatcherMatching(t *testing.T) {
+	testCases := []struct {
+		desc, yaml       string
+		path, recv, name string
+		constArgs        map[int]string
+		shouldMatch      bool
+	}{
+		{
+			desc: "Without ArgumentRE, every call matches",
+			yaml: `
+Package: os
+Method: Getenv`,
+			path:        "os",
+			name:        "Getenv",
+			shouldMatch: true,
+		},
+		{
+			desc: "ArgumentRE matches a constant argument",
+			yaml: `
+Package: os
+Method: Getenv
+ArgumentRE: PASSWORD`,
+			path:        "os",
+			name:        "Getenv",
+			constArgs:   map[int]string{0: "DB_PASSWORD"},
+			shouldMatch: true,
+		},
+		{
+			desc: "ArgumentRE does not match a different constant argument",
+			yaml: `
+Package: os
+Method: Getenv
+ArgumentRE: PASSWORD`,
+			path:        "os",
+			name:        "Getenv",
+			constArgs:   map[int]string{0: "HOME"},
+			shouldMatch: false,
+		},
+		{
+			desc: "ArgumentRE does not match without constant arguments",
+			yaml: `
+Package: os
+Method: Getenv
+ArgumentRE: PASSWORD`,
+			path:        "os",
+			name:        "Getenv",
+			shouldMatch: false,
+		},
+		{
+			desc: "Argument restricts the position of the matching argument",
+			yaml: `
+Package: flag
+Method: String
+Argument: 0
+ArgumentRE: password`,
+			path:        "flag",
+			name:        "String",
+			constArgs:   map[int]string{0: "user", 2: "not a password"},
+			shouldMatch: false,
+		},
+		{
+			desc: "Function must match",
+			yaml: `
+Package: os
+Method: Getenv
+ArgumentRE: PASSWORD`,
+			path:        "os",
+			name:        "Setenv",
+			constArgs:   map[int]string{0: "DB_PASSWORD"},
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sf := sourceFuncMatcher{}
+			if err := yaml.UnmarshalStrict([]byte(tc.yaml), &sf); err != nil {
+				t.Fatalf("unexpected error unmarshalling sourceFuncMatcher: %v", err)
+			}
+
+			if got := sf.MatchCall(tc.path, tc.recv, tc.name, tc.constArgs); got != tc.shouldMatch {
+				t.Errorf("MatchCall(%q, %q, %q, %v) got %v, want %v", tc.path, tc.recv, tc.name, tc.constArgs, got, tc.shouldMatch)
+			}
+		})
+	}
+}
+
+func TestSourceFuncMatcherUnmarshalErrorCases(t *testing.T) {
+	testCases := []struct {
+		desc, yaml string
+	}{
+		{
+			desc: "Unmarshaling is strict",
+			yaml: `
+Blahblah: foo
+ArgumentRE: bar`,
+		},
+		{
+			desc: "Argument must not be negative",
+			yaml: `
+Argument: -1
+ArgumentRE: bar`,
+		},
+		{
+			desc: "Argument requires ArgumentRE",
+			yaml: `
+Method: foo
+Argument: 0`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sf := sourceFuncMatcher{}
+			if err := yaml.UnmarshalStrict([]byte(tc.yaml), &sf); err == nil {
+				t.Error("got err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestMapLookupMatcher(t *testing.T) {
+	ml := mapLookupMatcher{}
+	if err := yaml.UnmarshalStrict([]byte(`
+Package: net/http
+Type: Header
+KeyRE: "^Cookie$"`), &ml); err != nil {
+		t.Fatalf("unexpected error unmarshalling mapLookupMatcher: %v", err)
+	}
+
+	if !ml.MatchLookup("net/http", "Header", "Cookie") {
+		t.Error("MatchLookup(net/http, Header, Cookie) got false, want true")
+	}
+	if ml.MatchLookup("net/http", "Header", "Accept") {
+		t.Error("MatchLookup(net/http, Header, Accept) got true, want false")
+	}
+	if ml.MatchLookup("net/url", "Values", "Cookie") {
+		t.Error("MatchLookup(net/url, Values, Cookie) got true, want false")
+	}
+
+	if err := yaml.UnmarshalStrict([]byte(`Type: Header`), &ml); err == nil {
+		t.Error("got err = nil when unmarshalling a mapLookupMatcher without KeyRE, want error")
+	}
+}
+
+func TestParamMatcherMatching(t *testing.T) {
+	const handlerSig = "func(net/http.ResponseWriter, *net/http.Request)"
+	testCases := []struct {
+		desc, yaml string
+		path       string
+		name       string
+		sig        string
+		index      int
+		paramName  string
+		want       bool
+	}{
+		{
+			desc: "matching signature and position",
+			yaml: `
+Signature: "func(net/http.ResponseWriter, *net/http.Request)"
+Parameter: 1`,
+			path: "example.com/server", name: "Handle", sig: handlerSig, index: 1, paramName: "r",
+			want: true,
+		},
+		{
+			desc: "matching signature, other position",
+			yaml: `
+Signature: "func(net/http.ResponseWriter, *net/http.Request)"
+Parameter: 1`,
+			path: "example.com/server", name: "Handle", sig: handlerSig, index: 0, paramName: "w",
+			want: false,
+		},
+		{
+			desc: "other signature",
+			yaml: `
+Signature: "func(net/http.ResponseWriter, *net/http.Request)"
+Parameter: 1`,
+			path: "example.com/server", name: "Handle", sig: "func(*net/http.Request)", index: 0, paramName: "r",
+			want: false,
+		},
+		{
+			desc: "matching function and parameter name",
+			yaml: `
+Package: example.com/server
+Method: Login
+ParameterNameRE: (?i)password`,
+			path: "example.com/server", name: "Login", sig: "func(string, string)", index: 1, paramName: "password",
+			want: true,
+		},
+		{
+			desc: "matching function, other parameter name",
+			yaml: `
+Package: example.com/server
+Method: Login
+ParameterNameRE: (?i)password`,
+			path: "example.com/server", name: "Login", sig: "func(string, string)", index: 0, paramName: "user",
+			want: false,
+		},
+		{
+			desc: "other function",
+			yaml: `
+Package: example.com/server
+Method: Login
+ParameterName: password`,
+			path: "example.com/server", name: "Logout", sig: "func(string, string)", index: 1, paramName: "password",
+			want: false,
+		},
+		{
+			desc: "no parameter matcher matches all parameters",
+			yaml: `
+Package: example.com/server
+Method: Login`,
+			path: "example.com/server", name: "Login", sig: "func(string, string)", index: 0, paramName: "user",
+			want: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pm := paramMatcher{}
+			if err := yaml.UnmarshalStrict([]byte(tc.yaml), &pm); err != nil {
+				t.Fatalf("unexpected error unmarshalling paramMatcher: %v", err)
+			}
+			if got := pm.MatchParameter(tc.path, "", tc.name, tc.sig, tc.index, tc.paramName); got != tc.want {
+				t.Errorf("MatchParameter(%q, %q, %q, %d, %q) = %v, want %v", tc.path, tc.name, tc.sig, tc.index, tc.paramName, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParamMatcherUnmarshalErrorCases(t *testing.T) {
+	testCases := []struct {
+		desc, yaml string
+	}{
+		{
+			desc: "Unmarshaling is strict",
+			yaml: `
+Blahblah: foo
+Method: bar`,
+		},
+		{
+			desc: "Parameter must not be negative",
+			yaml: `
+Method: foo
+Parameter: -1`,
+		},
+		{
+			desc: "Only one of ParameterName, ParameterNameRE",
+			yaml: `
+Method: foo
+ParameterName: bar
+ParameterNameRE: bar`,
+		},
+		{
+			desc: "Method or Signature is required",
+			yaml: `
+Package: foo
+Parameter: 0`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pm := paramMatcher{}
+			if err := yaml.UnmarshalStrict([]byte(tc.yaml), &pm); err == nil {
+				t.Error("got err = nil, want error")
+			}
+		})
+	}
+}
+
+func TestSinkArgument(t *testing.T) {
+	conf := Config{}
+	if err := yaml.UnmarshalStrict([]byte(`
+Sinks:
+- Package: database/sql
+  Receiver: "*DB"
+  Method: Query
+  Argument: 0
+- Package: database/sql
+  Receiver: "*DB"`), &conf); err != nil {
+		t.Fatalf("unexpected error unmarshalling Config: %v", err)
+	}
+
+	if arg, ok := conf.SinkArgument("database/sql", "*DB", "Query"); !ok || arg != 0 {
+		t.Errorf("SinkArgument(database/sql, *DB, Query) = %d, %v, want 0, true", arg, ok)
+	}
+	if _, ok := conf.SinkArgument("database/sql", "*DB", "Exec"); ok {
+		t.Error("SinkArgument(database/sql, *DB, Exec) got ok = true, want false")
+	}
+}
+
+func TestVariableMatcher(t *testing.T) {
+	vm := variableMatcher{}
+	if err := yaml.UnmarshalStrict([]byte(`
+Package: os
+Name: Args`), &vm); err != nil {
+		t.Fatalf("unexpected error unmarshalling variableMatcher: %v", err)
+	}
+
+	if !vm.MatchVariable("os", "Args") {
+		t.Error("MatchVariable(os, Args) got false, want true")
+	}
+	if vm.MatchVariable("os", "Stdin") {
+		t.Error("MatchVariable(os, Stdin) got true, want false")
+	}
+
+	if err := yaml.UnmarshalStrict([]byte(`Package: os`), &vm); err == nil {
+		t.Error("got err = nil when unmarshalling a variableMatcher without Name or NameRE, want error")
+	}
+	if err := yaml.UnmarshalStrict([]byte(`
+Name: Args
+NameRE: Args`), &vm); err == nil {
+		t.Error("got err = nil when unmarshalling a variableMatcher with both Name and NameRE, want error")
+	}
+}
+
+func TestInterfaceMatcherUnmarshalling(t *testing.T) {
+	testCases := []struct {
+		desc, yaml string
+		wantErr    bool
+	}{
+		{
+			desc: "named interface",
+			yaml: `
+Package: example.com/datapolicy
+Interface: Secret`,
+		},
+		{
+			desc: "methods",
+			yaml: `Methods: [Sensitive]`,
+		},
+		{
+			desc:    "missing interface",
+			yaml:    `Package: example.com/datapolicy`,
+			wantErr: true,
+		},
+		{
+			desc:    "missing package",
+			yaml:    `Interface: Secret`,
+			wantErr: true,
+		},
+		{
+			desc: "named interface and methods",
+			yaml: `
+Package: example.com/datapolicy
+Interface: Secret
+Methods: [Sensitive]`,
+			wantErr: true,
+		},
+		{
+			desc:    "empty method name",
+			yaml:    `Methods: [""]`,
+			wantErr: true,
+		},
+		{
+			desc:    "empty",
+			yaml:    `{}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			im := interfaceMatcher{}
+			err := yaml.UnmarshalStrict([]byte(tc.yaml), &im)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got err = %v, expect err = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestProtoOptionMatcherUnmarshalling(t *testing.T) {
+	testCases := []struct {
+		desc, yaml string
+		wantErr    bool
+	}{
+		{
+			desc: "name",
+			yaml: `Name: "(privacy.sensitive)"`,
+		},
+		{
+			desc: "name and number",
+			yaml: `
+Name: legacy.secret
+Number: 51234`,
+		},
+		{
+			desc:    "missing name",
+			yaml:    `Number: 51234`,
+			wantErr: true,
+		},
+		{
+			desc: "negative number",
+			yaml: `
+Name: legacy.secret
+Number: -1`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pm := protoOptionMatcher{}
+			err := yaml.UnmarshalStrict([]byte(tc.yaml), &pm)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got err = %v, expect err = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSourceHeuristicUnmarshalling(t *testing.T) {
+	testCases := []struct {
+		desc, yaml string
+		wantErr    bool
+	}{
+		{
+			desc: "field name",
+			yaml: `
+FieldRE: "(?i)secret"
+Score: 2`,
+		},
+		{
+			desc: "negative score",
+			yaml: `
+TypeRE: "^bool$"
+Score: -2`,
+		},
+		{
+			desc: "tag",
+			yaml: `
+Tag:
+  Key: datapolicy
+  ValueRE: "^pii"
+Score: 2`,
+		},
+		{
+			desc: "invalid tag",
+			yaml: `
+Tag:
+  Key: datapolicy
+Score: 2`,
+			wantErr: true,
+		},
+		{
+			desc:    "missing matcher",
+			yaml:    `Score: 2`,
+			wantErr: true,
+		},
+		{
+			desc:    "missing score",
+			yaml:    `FieldRE: "(?i)secret"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sh := sourceHeuristic{}
+			err := yaml.UnmarshalStrict([]byte(tc.yaml), &sh)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got err = %v, expect err = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"go/types"
+	"testing"
+)
+
+const overridesConfig = `
+Sources:
+- Package: core
+  Type: Credentials
+Sinks:
+- Package: core
+  Method: Log
+ReportMessage: default
+Overrides:
+- PackageRE: "/cmd/"
+  AddSinks:
+  - Package: core
+    Method: Upload
+  RemoveSinks:
+  - Package: core
+    Method: Log
+  ReportMessage: cmd
+- PackageRE: "/testutil$"
+  RemoveSources:
+  - Package: core
+    Type: Credentials
+    Field: Token
+  AllowPanicOnTaintedValues: true
+`
+
+func TestForPackage(t *testing.T) {
+	conf, err := Parse([]byte(overridesConfig))
+	if err != nil {
+		t.Fatalf("unexpected error parsing overrides: %v", err)
+	}
+
+	server := conf.ForPackage(types.NewPackage("example.com/server", "server"))
+	if !server.IsSink("core", "", "Log") || server.IsSink("core", "", "Upload") || server.ReportMessage != "default" {
+		t.Error("in example.com/server, no override should apply")
+	}
+
+	cmd := conf.ForPackage(types.NewPackage("example.com/cmd/tool", "main"))
+	if cmd.IsSink("core", "", "Log") || !cmd.IsSink("core", "", "Upload") {
+		t.Error("in example.com/cmd/tool, Log should not be a sink, and Upload should be a sink")
+	}
+	if cmd.ReportMessage != "cmd" {
+		t.Errorf("in example.com/cmd/tool, got ReportMessage = %q, want %q", cmd.ReportMessage, "cmd")
+	}
+	if !conf.IsSink("core", "", "Log") || conf.IsSink("core", "", "Upload") || conf.ReportMessage != "default" {
+		t.Error("ForPackage should not modify the original configuration")
+	}
+
+	testutil := conf.ForPackage(types.NewPackage("example.com/testutil", "testutil"))
+	if !testutil.IsSourceType("core", "Credentials") {
+		t.Error("in example.com/testutil, Credentials should still be a source type")
+	}
+	if testutil.IsSourceField("core", "Credentials", "Token") || !testutil.IsSourceField("core", "Credentials", "Password") {
+		t.Error("in example.com/testutil, Credentials.Token should not be a source field, and Credentials.Password should be")
+	}
+	if !testutil.AllowPanicOnTaintedValues || conf.AllowPanicOnTaintedValues {
+		t.Error("AllowPanicOnTaintedValues should only be set in example.com/testutil")
+	}
+}
+
+func TestOverrideRequiresPackageRE(t *testing.T) {
+	if _, err := Parse([]byte(`
+Overrides:
+- AllowPanicOnTaintedValues: true`)); err == nil {
+		t.Error("got err = nil when parsing an override without PackageRE, want error")
+	}
+}
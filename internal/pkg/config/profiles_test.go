@@ -0,0 +1,116 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+func TestProfiles(t *testing.T) {
+	conf, err := Parse([]byte(`
+Sinks:
+- Method: Log
+Profiles:
+- Name: injection
+  Sinks:
+  - Method: Query
+    Argument: 0
+- Name: audit
+  Sinks:
+  - Method: Audit`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing profiles: %v", err)
+	}
+
+	if len(conf.Profiles) != 2 {
+		t.Fatalf("got %d profiles, want 2", len(conf.Profiles))
+	}
+	if !conf.IsSink("", "", "Log") || conf.IsSink("", "", "Query") {
+		t.Error("top-level sinks should not include the sinks of profiles")
+	}
+	injection := conf.Profiles[0]
+	if injection.Name != "injection" || !injection.IsSink("", "", "Query") || injection.IsSink("", "", "Log") {
+		t.Errorf("profile %q should only include its own sinks", injection.Name)
+	}
+}
+
+func TestProfileErrorCases(t *testing.T) {
+	testCases := []struct {
+		desc, yaml string
+	}{
+		{
+			desc: "Profiles must be named",
+			yaml: `
+Profiles:
+- Sinks:
+  - Method: Log`,
+		},
+		{
+			desc: "Profile names must be unique",
+			yaml: `
+Profiles:
+- Name: foo
+- Name: foo`,
+		},
+		{
+			desc: "Profiles cannot be nested",
+			yaml: `
+Profiles:
+- Name: foo
+  Profiles:
+  - Name: bar`,
+		},
+		{
+			desc: "FieldTags are shared",
+			yaml: `
+Profiles:
+- Name: foo
+  FieldTags:
+  - Key: foo
+    Value: bar`,
+		},
+		{
+			desc: "ProtoFieldOptions are shared",
+			yaml: `
+Profiles:
+- Name: foo
+  ProtoFieldOptions:
+  - Name: privacy.sensitive`,
+		},
+		{
+			desc: "Summaries are shared",
+			yaml: `
+Profiles:
+- Name: foo
+  Summaries:
+  - Method: Encode
+    IfTainted: [0]
+    TaintedRets: [0]`,
+		},
+		{
+			desc: "Redacted formatting is only supported at the top level",
+			yaml: `
+Profiles:
+- Name: foo
+  AllowRedactedFormatting: true`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := Parse([]byte(tc.yaml)); err == nil {
+				t.Error("got err = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestSensitiveKeys(t *testing.T) {
+	cases := []struct {
+		desc   string
+		config string
+		key    string
+		want   bool
+	}{
+		{
+			desc:   "no regexp configured",
+			config: `ReportMessage: "test"`,
+			key:    "password",
+			want:   false,
+		},
+		{
+			desc:   "matching key",
+			config: `SensitiveKeyRE: "password|token"`,
+			key:    "password",
+			want:   true,
+		},
+		{
+			desc:   "partially matching key",
+			config: `SensitiveKeyRE: "password|token"`,
+			key:    "session_token",
+			want:   true,
+		},
+		{
+			desc:   "case insensitive regexp",
+			config: `SensitiveKeyRE: "(?i)password|token"`,
+			key:    "Password",
+			want:   true,
+		},
+		{
+			desc:   "non-matching key",
+			config: `SensitiveKeyRE: "password|token"`,
+			key:    "user",
+			want:   false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.desc, func(t *testing.T) {
+			conf := new(Config)
+			if err := yaml.UnmarshalStrict([]byte(tt.config), conf); err != nil {
+				t.Fatalf("unexpected error unmarshalling config: %v", err)
+			}
+			if got := conf.IsSensitiveKey(tt.key); got != tt.want {
+				t.Errorf("IsSensitiveKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
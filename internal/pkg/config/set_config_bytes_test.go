@@ -31,7 +31,7 @@ func TestSetConfigBytes(t *testing.T) {
 		t.Fatalf("ReadConfig returned an unexpected error: %v", err)
 	}
 
-	if diff := cmp.Diff(set, read); diff != "" {
+	if diff := cmp.Diff(set, read, cmp.AllowUnexported(Config{})); diff != "" {
 		t.Errorf("set config differs from read config (-set, +read):\n%s", diff)
 	}
 }
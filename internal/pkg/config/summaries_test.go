@@ -0,0 +1,106 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummaries(t *testing.T) {
+	conf, err := Parse([]byte(`
+Summaries:
+- Package: example.com/lib
+  Receiver: "*Client"
+  Method: Encode
+  IfTainted: [0, 1]
+  TaintedRets: [0]
+- PackageRE: ^example.com/
+  MethodRE: ^Copy
+  IfTainted: [1]
+  TaintedArgs: [0]
+- Package: example.com/lib
+  Method: Join
+  IfTainted: [64]
+  TaintedRets: [0]
+Profiles:
+- Name: injection
+  Sinks:
+  - Method: Query`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing summaries: %v", err)
+	}
+
+	cases := []struct {
+		path, recv, name string
+		want             FuncSummary
+		wantOK           bool
+	}{
+		{"example.com/lib", "*Client", "Encode", FuncSummary{IfTainted: []int{0, 1}, TaintedRets: []int{0}}, true},
+		{"example.com/lib", "Client", "Encode", FuncSummary{}, false},
+		{"example.com/other", "", "CopyAll", FuncSummary{IfTainted: []int{1}, TaintedArgs: []int{0}}, true},
+		{"other.com/lib", "", "CopyAll", FuncSummary{}, false},
+		{"example.com/lib", "", "Join", FuncSummary{IfTainted: []int{64}, TaintedRets: []int{0}}, true},
+	}
+	for _, tc := range cases {
+		got, ok := conf.Summary(tc.path, tc.recv, tc.name)
+		if ok != tc.wantOK || !cmp.Equal(tc.want, got) {
+			t.Errorf("Summary(%q, %q, %q) = %v, %v, want %v, %v", tc.path, tc.recv, tc.name, got, ok, tc.want, tc.wantOK)
+		}
+	}
+
+	if _, ok := conf.Profiles[0].Summary("example.com/lib", "*Client", "Encode"); !ok {
+		t.Error("profiles should share the top-level summaries")
+	}
+}
+
+func TestSummaryErrorCases(t *testing.T) {
+	testCases := []struct {
+		desc, yaml string
+	}{
+		{
+			desc: "IfTainted is required",
+			yaml: `
+Summaries:
+- Method: Encode
+  TaintedRets: [0]`,
+		},
+		{
+			desc: "Positions must not be negative",
+			yaml: `
+Summaries:
+- Method: Encode
+  IfTainted: [-1]
+  TaintedRets: [0]`,
+		},
+		{
+			desc: "Unknown fields are rejected",
+			yaml: `
+Summaries:
+- Method: Encode
+  IfTainted: [0]
+  TaintedResults: [0]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := Parse([]byte(tc.yaml)); err == nil {
+				t.Error("got err = nil, want error")
+			}
+		})
+	}
+}
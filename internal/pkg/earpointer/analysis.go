@@ -23,6 +23,8 @@ import (
 	"strings"
 
 	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/propagation/summary"
+	"github.com/google/go-flow-levee/internal/pkg/utils"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
@@ -618,41 +620,140 @@ func (vis *visitor) visitBuiltin(builtin *ssa.Builtin, instr ssa.Instruction) {
 	}
 }
 
-// Handle some known functions, e.g. in package "fmt".
+// Handle the functions that have a summary, e.g. in package "fmt".
+// A flow described by the summary is modeled using a field of the destination
+// pointing to the source, i.e. dst[i -> src], where the field is named after the
+// source's path, with the arguments numbered from 1 as in the call's operands.
+// The destination is the part of an argument or of the return value designated
+// by a path, e.g. a field of the returned struct, and similarly for the source.
 func (vis *visitor) visitKnownFunction(fn *ssa.Function, instr ssa.Instruction) bool {
-	// TODO(#312): Handle standard library functions.
-	// Add an operand reference to a field of the "dst", i.e. dst[index -> op].
-	addField := func(dst ssa.Value, op ssa.Value, index int) {
-		fd := Field{Name: strconv.Itoa(index)}
-		if mayShareObject(op) {
-			for _, c := range vis.getContexts(dst) {
-				vis.unifyField(c, dst, fd, op)
+	summ := summary.ExtendedForFunction(fn)
+	if summ == nil {
+		return false
+	}
+	call, ok := instr.(ssa.CallInstruction)
+	if !ok {
+		return false
+	}
+	// As in a summary, the receiver of an invoked method is the first argument.
+	var args []ssa.Value
+	if call.Common().IsInvoke() {
+		args = append(args, call.Common().Value)
+	}
+	args = append(args, call.Common().Args...)
+	ret, hasRet := instr.(ssa.Value)
+	for _, c := range vis.getFunctionContexts(instr.Parent()) {
+		// Collect the sources, keyed by the fields that will point to them.
+		srcs := make(map[Field]Reference)
+		for _, p := range summ.IfTainted {
+			if p.Index >= len(args) || !mayShareObject(args[p.Index]) {
+				continue
+			}
+			arg := args[p.Index]
+			if src := vis.pathReference(MakeReference(c, arg), arg.Type(), p.Accesses); src != nil {
+				label := summary.Path{Index: p.Index + 1, Accesses: p.Accesses}
+				srcs[Field{Name: label.String()}] = src
+			}
+		}
+		// Collect the destinations.
+		var dsts []Reference
+		for _, p := range summ.TaintedArgs {
+			if p.Index < len(args) && mayShareObject(args[p.Index]) {
+				arg := args[p.Index]
+				dsts = append(dsts, vis.pathReference(MakeReference(c, arg), arg.Type(), p.Accesses))
+			}
+		}
+		if hasRet && mayShareObject(ret) {
+			for _, p := range summ.TaintedRets {
+				ref, tp := Reference(MakeReference(c, ret)), ret.Type()
+				// Model the element of a tuple as a field, as in visitExtract.
+				if tuple, ok := tp.(*types.Tuple); ok {
+					if p.Index >= tuple.Len() {
+						continue
+					}
+					ref, tp = vis.fieldReference(ref, Field{Name: strconv.Itoa(p.Index)}), tuple.At(p.Index).Type()
+				}
+				dsts = append(dsts, vis.pathReference(ref, tp, p.Accesses))
+			}
+		}
+		// Make each destination point to the sources.
+		for _, dst := range dsts {
+			if dst == nil {
+				continue
+			}
+			for fd, src := range srcs {
+				vis.unifyReferenceField(dst, fd, src)
 			}
 		}
 	}
-	fname := fn.Name()
-	switch {
-	case strings.HasPrefix(fname, "Sprint"), strings.HasPrefix(fname, "Errorf"):
-		// Propagate the arguments to the return value using fields.
-		if dst, ok := instr.(ssa.Value); ok {
-			ops := instr.Operands(nil)
-			// ops[0] is the function itself.
-			for i := 1; i < len(ops); i++ {
-				addField(dst, *ops[i], i)
+	return true
+}
+
+// Return the reference holding the part of a value designated by "accesses",
+// where "ref" holds the value and "tp" is its type. The references along the
+// path are synthesized if they don't exist. Return nil if the path does not
+// match the type.
+func (vis *visitor) pathReference(ref Reference, tp types.Type, accesses []summary.Access) Reference {
+	for _, a := range accesses {
+		var fd Field
+		var elem types.Type
+		if a.Field != "" {
+			fvar := summary.SelectField(tp, a.Field)
+			if fvar == nil {
+				return nil
+			}
+			fd, elem = Field{Name: fvar.Name(), irField: fvar}, fvar.Type()
+		} else {
+			switch t := utils.Dereference(tp).Underlying().(type) {
+			case *types.Slice:
+				elem = t.Elem()
+			case *types.Array:
+				elem = t.Elem()
+			default:
+				return nil
+			}
+			fd = anyIndexField
+			if a.Element != summary.AnyElement {
+				fd = Field{Name: strconv.Itoa(a.Element)}
 			}
 		}
-		return true
-	case strings.HasPrefix(fname, "Fprint"):
-		// Propagate the arguments to the first argument using fields.
-		ops := instr.Operands(nil)
-		// ops[0] is the function itself.
-		first := *ops[1] // the io stream
-		for i := 2; i < len(ops); i++ {
-			addField(first, *ops[i], i)
+		// The fields of the object pointed to by a pointer or a slice hold
+		// the addresses of the fields, as in visitFieldAddr and visitIndexAddr.
+		// The fields of a struct or array value hold the values themselves.
+		switch tp.Underlying().(type) {
+		case *types.Pointer, *types.Slice:
+			ref = vis.getPointee(vis.fieldReference(vis.getPointee(ref), fd))
+		default:
+			ref = vis.fieldReference(ref, fd)
 		}
-		return true
-	default:
-		return false
+		tp = elem
+	}
+	return ref
+}
+
+// Return the reference of a field of "obj", and synthesize it if it doesn't exist.
+// Note that the synthesized fields of an object share a reference.
+func (vis *visitor) fieldReference(obj Reference, fd Field) Reference {
+	state := vis.state
+	objr := state.representative(obj)
+	fmap := state.PartitionFieldMap(objr)
+	if v, ok := fmap[fd]; ok {
+		return v
+	}
+	v := state.Insert(MakeSynthetic(SyntheticField, objr))
+	fmap[fd] = v
+	return v
+}
+
+// Unify "obj.field" and "target" for references "obj" and "target".
+func (vis *visitor) unifyReferenceField(obj Reference, fd Field, target Reference) {
+	state := vis.state
+	fmap := state.PartitionFieldMap(state.representative(obj))
+	tr := state.representative(target)
+	if v, ok := fmap[fd]; ok {
+		state.Unify(v, tr)
+	} else {
+		fmap[fd] = tr
 	}
 }
 
@@ -962,7 +1063,12 @@ func (vis *visitor) unifyByValue(ref1 Reference, ref2 Reference) {
 
 // Return all the calling contexts of the function to which a value belongs.
 func (vis *visitor) getContexts(v ssa.Value) []*Context {
-	if fn := v.Parent(); fn != nil {
+	return vis.getFunctionContexts(v.Parent())
+}
+
+// Return all the calling contexts of a function.
+func (vis *visitor) getFunctionContexts(fn *ssa.Function) []*Context {
+	if fn != nil {
 		if ctxs, ok := vis.contexts[fn]; ok {
 			return ctxs
 		}
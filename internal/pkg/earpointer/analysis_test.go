@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-flow-levee/internal/pkg/earpointer"
+	"github.com/google/go-flow-levee/internal/pkg/propagation/summary"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/ssa"
@@ -1101,6 +1102,85 @@ func TestKnownCall(t *testing.T) {
 	}
 }
 
+func TestSummarizedCallToField(t *testing.T) {
+	code := `package p
+	import "net/http"
+	func f(r *http.Request, s string) {
+		r.SetBasicAuth("user", s)
+		_ = r.Header
+	}
+	`
+	/*
+		func f(r *net/http.Request, s string):
+		0:                                                                entry P:0 S:0
+			t0 = (*net/http.Request).SetBasicAuth(r, "user":string, s)   ()
+			t1 = &r.Header [#5]                                       *net/http.Header
+			t2 = *t1                                                   net/http.Header
+			return
+	*/
+	state, err := runCodeK0(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// f.s is pointed by a field of r.Header, i.e. f.t2.
+	want := concat(map[string]string{
+		"{**f.r[.],f.t2}": "[3->f.s]",
+		"{*f.r[.],f.t1}":  "--> f.t2",
+		"{*f.r}":          "[Header->f.t1]",
+		"{f.r}":           "--> *f.r",
+		"{f.s}":           "[]",
+	})
+	// Exclude the references in package "net/http".
+	if !strings.Contains(state.String(), want) {
+		t.Errorf("want:\n%s", want)
+	}
+}
+
+func TestSummarizedCallFromVariadicElement(t *testing.T) {
+	summary.ExtendedFuncSummaries["t.g"] = summary.Extended{
+		IfTainted:   []summary.Path{summary.At(0).Element(1)},
+		TaintedRets: []summary.Path{summary.At(0)},
+	}
+	defer delete(summary.ExtendedFuncSummaries, "t.g")
+	code := `package p
+	func g(ks ...*int) *int {
+		return nil
+	}
+	func f(a *int, b *int) *int {
+		return g(a, b)
+	}
+	`
+	/*
+		func f(a *int, b *int) *int:
+		0:                                                     entry P:0 S:0
+			t0 = new [2]*int (varargs)                         *[2]*int
+			t1 = &t0[0:int]                                    **int
+			*t1 = a
+			t2 = &t0[1:int]                                    **int
+			*t2 = b
+			t3 = slice t0[:]                                   []*int
+			t4 = g(t3...)                                      *int
+			return t4
+	*/
+	state, err := runCodeK0(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the second element, i.e. f.b, is pointed by a field of f.t4.
+	// The summary is used instead of g's body.
+	want := concat(map[string]string{
+		"{*f.t0}":     "[0->f.t2, 1->f.t2, AnyField->f.t2]",
+		"{f.a,f.b}":   "[]",
+		"{f.t0,f.t3}": "--> *f.t0",
+		"{f.t1,f.t2}": "--> f.b",
+		"{f.t4}":      "[1[1]->f.b]",
+		"{g.ks}":      "[]",
+	})
+	if diff := cmp.Diff(want, state.String()); diff != "" {
+		t.Errorf("diff (-want +got):\n%s", diff)
+	}
+}
+
 func TestVariadicCall(t *testing.T) {
 	code := `package p
 	func g(ks ...*int) *int {
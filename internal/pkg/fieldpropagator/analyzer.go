@@ -22,23 +22,37 @@ import (
 
 	"github.com/google/go-flow-levee/internal/pkg/config"
 	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
+	"github.com/google/go-flow-levee/internal/pkg/formatter"
 	"github.com/google/go-flow-levee/internal/pkg/propagation"
-	"github.com/google/go-flow-levee/internal/pkg/utils"
+	"github.com/google/go-flow-levee/internal/pkg/sourcetype"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/ssa"
 )
 
-// ResultType is a set of objects that are field propagators.
-type ResultType map[types.Object]bool
+// ResultType maps the name of each configuration to the field propagators
+// identified with it. The top-level configuration is unnamed.
+type ResultType map[string]Propagators
+
+// For returns the field propagators identified with a configuration.
+func (r ResultType) For(conf *config.Config) Propagators {
+	return r[conf.Name]
+}
+
+// Propagators is a set of objects that are field propagators.
+type Propagators map[types.Object]bool
 
 // IsFieldPropagator determines whether a call is a field propagator.
-func (r ResultType) IsFieldPropagator(c *ssa.Call) bool {
+func (p Propagators) IsFieldPropagator(c *ssa.Call) bool {
 	cf, ok := c.Call.Value.(*ssa.Function)
-	return ok && r[cf.Object()]
+	return ok && p[cf.Object()]
 }
 
-type isFieldPropagator struct{}
+// isFieldPropagator records the names of the configurations
+// with which a function is a field propagator.
+type isFieldPropagator struct {
+	Configs []string
+}
 
 func (i isFieldPropagator) AFact() {}
 
@@ -53,13 +67,14 @@ var Analyzer = &analysis.Analyzer{
 A field propagator is a function that returns a value that is tainted by a source field.`,
 	Flags:      config.FlagSet,
 	Run:        run,
-	Requires:   []*analysis.Analyzer{buildssa.Analyzer, fieldtags.Analyzer},
+	Requires:   []*analysis.Analyzer{buildssa.Analyzer, fieldtags.Analyzer, formatter.Analyzer},
 	ResultType: reflect.TypeOf(new(ResultType)).Elem(),
 	FactTypes:  []analysis.Fact{new(isFieldPropagator)},
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
 	taggedFields := pass.ResultOf[fieldtags.Analyzer].(fieldtags.ResultType)
+	formatters := pass.ResultOf[formatter.Analyzer].(formatter.ResultType)
 	ssaInput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
 
 	conf, err := config.ReadConfig()
@@ -67,22 +82,33 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		return nil, err
 	}
 
+	// Methods declared in excluded files are analyzed regardless,
+	// since callers in other files rely on the facts exported for them.
+	// Each profile may declare its own source types, so it is analyzed separately.
 	ssaProg := ssaInput.Pkg.Prog
-	for _, mem := range ssaInput.Pkg.Members {
-		ssaType, ok := mem.(*ssa.Type)
-		if !ok || !conf.IsSourceType(utils.DecomposeType(ssaType.Type())) {
-			continue
-		}
-		for _, meth := range methods(ssaProg, ssaType.Type()) {
-			analyzeBlocks(pass, conf, taggedFields, meth)
+	for _, c := range conf.WithProfiles() {
+		c = c.ForPackage(pass.Pkg)
+		for _, mem := range ssaInput.Pkg.Members {
+			ssaType, ok := mem.(*ssa.Type)
+			if !ok || !sourcetype.IsSourceType(c, taggedFields, ssaType.Type()) {
+				continue
+			}
+			for _, meth := range methods(ssaProg, ssaType.Type()) {
+				analyzeBlocks(pass, c, taggedFields, formatters, meth)
+			}
 		}
 	}
 
-	isFieldPropagator := map[types.Object]bool{}
+	result := ResultType{}
 	for _, f := range pass.AllObjectFacts() {
-		isFieldPropagator[f.Object] = true
+		for _, name := range f.Fact.(*isFieldPropagator).Configs {
+			if result[name] == nil {
+				result[name] = Propagators{}
+			}
+			result[name][f.Object] = true
+		}
 	}
-	return ResultType(isFieldPropagator), nil
+	return result, nil
 }
 
 func methods(ssaProg *ssa.Program, t types.Type) []*ssa.Function {
@@ -105,7 +131,7 @@ func methodValues(ssaProg *ssa.Program, t types.Type) []*ssa.Function {
 	return methodValues
 }
 
-func analyzeBlocks(pass *analysis.Pass, conf *config.Config, tf fieldtags.ResultType, meth *ssa.Function) {
+func analyzeBlocks(pass *analysis.Pass, conf *config.Config, tf fieldtags.ResultType, formatters formatter.ResultType, meth *ssa.Function) {
 	var propagations []propagation.Propagation
 
 	for _, b := range meth.Blocks {
@@ -124,8 +150,8 @@ func analyzeBlocks(pass *analysis.Pass, conf *config.Config, tf fieldtags.Result
 			default:
 				continue
 			}
-			if conf.IsSourceField(utils.DecomposeField(txType, field)) || tf.IsSourceField(txType, field) {
-				propagations = append(propagations, propagation.Taint(instr.(ssa.Node), conf, tf))
+			if sourcetype.IsSourceField(conf, tf, txType, field) {
+				propagations = append(propagations, propagation.Taint(instr.(ssa.Node), conf, tf, formatters))
 			}
 		}
 	}
@@ -138,9 +164,24 @@ func analyzeBlocks(pass *analysis.Pass, conf *config.Config, tf fieldtags.Result
 			}
 			for _, prop := range propagations {
 				if prop.IsTainted(ret) {
-					pass.ExportObjectFact(meth.Object(), &isFieldPropagator{})
+					markFieldPropagator(pass, conf, meth.Object())
+					return
 				}
 			}
 		}
 	}
 }
+
+// markFieldPropagator records that a function is a field propagator
+// with a configuration, in addition to the configurations already recorded.
+func markFieldPropagator(pass *analysis.Pass, conf *config.Config, obj types.Object) {
+	fact := &isFieldPropagator{}
+	pass.ImportObjectFact(obj, fact)
+	for _, name := range fact.Configs {
+		if name == conf.Name {
+			return
+		}
+	}
+	fact.Configs = append(fact.Configs, conf.Name)
+	pass.ExportObjectFact(obj, fact)
+}
@@ -13,7 +13,9 @@
 // limitations under the License.
 
 // Package fieldtags defines an analyzer that identifies struct fields identified
-// as sources via a field tag.
+// as sources via a field tag, as well as fields that are tagged as safe.
+// The fields of generated protobuf messages are also identified as sources
+// if a configured custom option is set for them in their .proto file.
 package fieldtags
 
 import (
@@ -29,7 +31,8 @@ import (
 )
 
 // ResultType is a map from types.Object to bool.
-// It can be used to determine whether a field is a tagged Source field.
+// It can be used to determine whether a field is a tagged Source field (true),
+// or a field tagged as safe (false).
 type ResultType map[types.Object]bool
 
 var Analyzer = &analysis.Analyzer{
@@ -40,7 +43,7 @@ var Analyzer = &analysis.Analyzer{
 		inspect.Analyzer,
 	},
 	ResultType: reflect.TypeOf(new(ResultType)).Elem(),
-	FactTypes:  []analysis.Fact{new(isTaggedField)},
+	FactTypes:  []analysis.Fact{new(isTaggedField), new(isSafeField), new(protoExtensions)},
 }
 
 type isTaggedField struct{}
@@ -51,6 +54,14 @@ func (i isTaggedField) String() string {
 	return "tagged field"
 }
 
+type isSafeField struct{}
+
+func (i isSafeField) AFact() {}
+
+func (i isSafeField) String() string {
+	return "safe field"
+}
+
 func run(pass *analysis.Pass) (interface{}, error) {
 	conf, err := config.ReadConfig()
 	if err != nil {
@@ -69,21 +80,37 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			return
 		}
 		for _, f := range (*s).Fields.List {
-			if f.Tag == nil || len(f.Names) == 0 || !conf.IsSourceFieldTag(f.Tag.Value) {
+			if f.Tag == nil || len(f.Names) == 0 {
 				continue
 			}
-			fNames := make([]string, len(f.Names))
-			for i, ident := range f.Names {
-				fNames[i] = ident.Name
-				pass.ExportObjectFact(pass.TypesInfo.ObjectOf(ident), &isTaggedField{})
+			// A field tagged as safe is not a source, even if it is also tagged as a source.
+			var fact analysis.Fact
+			switch {
+			case conf.IsSafeFieldTag(f.Tag.Value):
+				fact = &isSafeField{}
+			case conf.IsSourceFieldTag(f.Tag.Value):
+				fact = &isTaggedField{}
+			default:
+				continue
+			}
+			for _, ident := range f.Names {
+				pass.ExportObjectFact(pass.TypesInfo.ObjectOf(ident), fact)
 			}
 		}
 	})
 
+	// Fields of generated protobuf messages may be sources by virtue of their options.
+	if len(conf.ProtoFieldOptions) > 0 {
+		for _, v := range protoSourceFields(pass, conf) {
+			pass.ExportObjectFact(v, &isTaggedField{})
+		}
+	}
+
 	// return all facts accumulated down the current path in the dependency graph
 	result := map[types.Object]bool{}
 	for _, f := range pass.AllObjectFacts() {
-		result[f.Object] = true
+		_, isSource := f.Fact.(*isTaggedField)
+		result[f.Object] = isSource
 	}
 
 	return ResultType(result), nil
@@ -102,3 +129,17 @@ func (r ResultType) IsSourceField(t types.Type, field int) bool {
 func (r ResultType) IsSource(field *types.Var) bool {
 	return r[(types.Object)(field)]
 }
+
+// IsSafeField determines whether a field on a type is tagged as safe,
+// using the type of the struct holding the field as well as the index
+// of the field.
+func (r ResultType) IsSafeField(t types.Type, field int) bool {
+	fieldVar := utils.Dereference(t).Underlying().(*types.Struct).Field(field)
+	return r.IsSafe(fieldVar)
+}
+
+// IsSafe determines whether a types.Var is a field that is tagged as safe.
+func (r ResultType) IsSafe(field *types.Var) bool {
+	isSource, tagged := r[(types.Object)(field)]
+	return tagged && !isSource
+}
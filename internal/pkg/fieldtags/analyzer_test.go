@@ -37,14 +37,20 @@ func TestFieldTagsAnalysis(t *testing.T) {
 		t.Fatalf("expected 2 results, got %d", len(results))
 	}
 
-	var gotCoreResults []string
-	for obj := range results[0].Result.(ResultType) {
-		gotCoreResults = append(gotCoreResults, obj.Name())
+	var gotCoreResults, gotCoreSafe []string
+	for obj, isSource := range results[0].Result.(ResultType) {
+		if isSource {
+			gotCoreResults = append(gotCoreResults, obj.Name())
+		} else {
+			gotCoreSafe = append(gotCoreSafe, obj.Name())
+		}
 	}
 	wantCoreResults := []string{
 		"adminSecret",
 		"another",
+		"apiKey",
 		"creds",
+		"email",
 		"hasCustomFieldTag",
 		"hasTagWithMultipleValues",
 		"password",
@@ -53,13 +59,45 @@ func TestFieldTagsAnalysis(t *testing.T) {
 	if diff := cmp.Diff(wantCoreResults, gotCoreResults, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
 		t.Errorf("core results diff (-want +got):\n%s", diff)
 	}
+	wantCoreSafe := []string{"avatar", "displayName", "greeting", "nickname"}
+	if diff := cmp.Diff(wantCoreSafe, gotCoreSafe, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("core safe fields diff (-want +got):\n%s", diff)
+	}
 
 	var gotCrosspkgResults []string
-	for obj := range results[1].Result.(ResultType) {
-		gotCrosspkgResults = append(gotCrosspkgResults, obj.Name())
+	for obj, isSource := range results[1].Result.(ResultType) {
+		if isSource {
+			gotCrosspkgResults = append(gotCrosspkgResults, obj.Name())
+		}
 	}
 	wantCrosspkgResults := append(wantCoreResults, "crossField")
 	if diff := cmp.Diff(wantCrosspkgResults, gotCrosspkgResults, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
 		t.Errorf("crosspkg results diff (-want +got):\n%s", diff)
 	}
 }
+
+func TestProtoFieldOptions(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	if err := config.FlagSet.Set("config", filepath.Join(testdata, "test-config.yaml")); err != nil {
+		t.Error(err)
+	}
+
+	results := analysistest.Run(t, testdata, Analyzer, "fieldtags_analysistest/protos/...")
+
+	var got []string
+	for _, r := range results {
+		if r.Pass.Pkg.Name() == "privacy" {
+			continue
+		}
+		for obj, isSource := range r.Result.(ResultType) {
+			if isSource && obj.Pkg() == r.Pass.Pkg {
+				got = append(got, obj.Name())
+			}
+		}
+	}
+	want := []string{"ApiKey", "Password", "Secret", "Token"}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("proto source fields diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,476 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fieldtags
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+// protoExtensions is a package fact that records the custom field options,
+// i.e. the extensions of google.protobuf.FieldOptions, that are declared
+// in the protobuf descriptors embedded in a package, by full name.
+type protoExtensions struct {
+	Numbers map[string]int32
+}
+
+func (p *protoExtensions) AFact() {}
+
+func (p *protoExtensions) String() string {
+	var names []string
+	for name := range p.Numbers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "proto field options: " + strings.Join(names, ", ")
+}
+
+// rawDescriptorName matches the names of the variables and constants holding the
+// descriptors embedded in files generated by protoc-gen-go, e.g. file_user_proto_rawDesc,
+// and by older versions of protoc-gen-go, e.g. fileDescriptor_116e343673f7ffaf.
+var rawDescriptorName = regexp.MustCompile(`^(file_\w+_rawDesc|fileDescriptor_\w+)$`)
+
+// protoSourceFields returns the fields of the generated message types of a package
+// for which one of the configured custom options is set, e.g. [(privacy.sensitive) = true].
+// The custom options declared in the package are exported as a fact, so that they can be
+// resolved in the packages that import it.
+func protoSourceFields(pass *analysis.Pass, conf *config.Config) []*types.Var {
+	var files []*protoFile
+	for _, raw := range rawDescriptors(pass) {
+		if f, err := decodeFile(raw); err == nil {
+			files = append(files, f)
+		}
+	}
+
+	declared := map[string]int32{}
+	for _, f := range files {
+		for _, ext := range f.extensions {
+			if strings.TrimPrefix(ext.extendee, ".") == "google.protobuf.FieldOptions" {
+				declared[ext.name] = ext.number
+			}
+		}
+	}
+	if len(declared) > 0 {
+		pass.ExportPackageFact(&protoExtensions{Numbers: declared})
+	}
+
+	known := map[string]int32{}
+	for _, pf := range pass.AllPackageFacts() {
+		if exts, ok := pf.Fact.(*protoExtensions); ok {
+			for name, number := range exts.Numbers {
+				known[name] = number
+			}
+		}
+	}
+	var numbers []int32
+	for _, opt := range conf.ProtoFieldOptions {
+		number := opt.Number
+		if number == 0 {
+			number = known[strings.TrimPrefix(strings.Trim(opt.Name, "()"), ".")]
+		}
+		if number != 0 {
+			numbers = append(numbers, number)
+		}
+	}
+	if len(numbers) == 0 {
+		return nil
+	}
+
+	var fields []*types.Var
+	for _, f := range files {
+		for _, m := range f.messages {
+			for _, pf := range m.fields {
+				if !hasOption(pf.options, numbers) {
+					continue
+				}
+				if v := goField(pass.Pkg, m, pf); v != nil {
+					fields = append(fields, v)
+				}
+			}
+		}
+	}
+	return fields
+}
+
+// rawDescriptors returns the serialized descriptors embedded in the files of a package.
+func rawDescriptors(pass *analysis.Pass) [][]byte {
+	var descs [][]byte
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.VAR && gd.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if !rawDescriptorName.MatchString(name.Name) || i >= len(vs.Values) {
+						continue
+					}
+					if b, ok := constantBytes(pass.TypesInfo, vs.Values[i]); ok {
+						descs = append(descs, gunzipIfCompressed(b))
+					}
+				}
+			}
+		}
+	}
+	return descs
+}
+
+// constantBytes evaluates an expression holding constant bytes,
+// e.g. a []byte literal, a string constant, or a conversion of a string constant.
+func constantBytes(info *types.Info, e ast.Expr) ([]byte, bool) {
+	if tv, ok := info.Types[e]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return []byte(constant.StringVal(tv.Value)), true
+	}
+	switch e := e.(type) {
+	case *ast.CallExpr:
+		if len(e.Args) == 1 {
+			return constantBytes(info, e.Args[0])
+		}
+	case *ast.CompositeLit:
+		b := make([]byte, 0, len(e.Elts))
+		for _, elt := range e.Elts {
+			tv, ok := info.Types[elt]
+			if !ok || tv.Value == nil {
+				return nil, false
+			}
+			v, exact := constant.Uint64Val(constant.ToInt(tv.Value))
+			if !exact || v > 0xff {
+				return nil, false
+			}
+			b = append(b, byte(v))
+		}
+		return b, true
+	}
+	return nil, false
+}
+
+// gunzipIfCompressed decompresses a descriptor if it is gzipped,
+// as older versions of protoc-gen-go do.
+func gunzipIfCompressed(b []byte) []byte {
+	if len(b) < 2 || b[0] != 0x1f || b[1] != 0x8b {
+		return b
+	}
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return b
+	}
+	if unzipped, err := ioutil.ReadAll(r); err == nil {
+		return unzipped
+	}
+	return b
+}
+
+// goField returns the field of a generated Go struct that corresponds to a field of a message.
+// Fields are identified by the field numbers recorded in their protobuf struct tags.
+// The fields of a oneof are declared in wrapper types, e.g. User_ApiKey.
+func goField(pkg *types.Package, m *protoMessage, pf protoField) *types.Var {
+	goName := goCamelCase(m.name)
+	for _, typeName := range []string{goName, goName + "_" + goCamelCase(pf.name)} {
+		tn, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		s, ok := tn.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		for i := 0; i < s.NumFields(); i++ {
+			if protoFieldNumber(s.Tag(i)) == pf.number {
+				return s.Field(i)
+			}
+		}
+	}
+	return nil
+}
+
+// protoFieldNumber returns the field number recorded in a protobuf struct tag,
+// e.g. 2 for `protobuf:"bytes,2,opt,name=password,proto3"`, or 0 if there is none.
+func protoFieldNumber(tag string) int32 {
+	parts := strings.Split(reflect.StructTag(tag).Get("protobuf"), ",")
+	if len(parts) < 2 {
+		return 0
+	}
+	n, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(n)
+}
+
+// goCamelCase converts the name of a message, relative to its package, to the name
+// of the corresponding Go type, following the conventions of protoc-gen-go,
+// e.g. "Outer.inner_message" becomes "Outer_InnerMessage".
+func goCamelCase(s string) string {
+	isLower := func(c byte) bool { return 'a' <= c && c <= 'z' }
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isLower(s[i+1]):
+			// Skip over '.' in ".{{lowercase}}".
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			// An initial '_' is converted, so that the name starts with a capital letter.
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isLower(s[i+1]):
+			// Skip over '_' in "_{{lowercase}}".
+		case '0' <= c && c <= '9':
+			b = append(b, c)
+		default:
+			// The next word starts with an upper case letter,
+			// and is followed by a sequence of lower case letters.
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isLower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+// hasOption determines whether one of the given options is set in serialized field options.
+// An option is set if it has a non-zero value, e.g. true, or a non-empty message.
+func hasOption(options []byte, numbers []int32) bool {
+	records, err := decodeRecords(options)
+	if err != nil {
+		return false
+	}
+	for _, r := range records {
+		for _, n := range numbers {
+			if r.number == n && (r.value != 0 || len(r.bytes) > 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// A protoFile holds the parts of a google.protobuf.FileDescriptorProto that are used
+// to identify the fields for which a custom option is set.
+type protoFile struct {
+	pkg        string
+	messages   []*protoMessage
+	extensions []protoField
+}
+
+// A protoMessage holds the parts of a google.protobuf.DescriptorProto that are used.
+// Its name is relative to the package, e.g. Outer.Inner for a nested message.
+type protoMessage struct {
+	name   string
+	fields []protoField
+}
+
+// A protoField holds the parts of a google.protobuf.FieldDescriptorProto that are used.
+// The name of an extension is its full name, e.g. privacy.sensitive.
+type protoField struct {
+	name     string
+	number   int32
+	extendee string
+	options  []byte
+}
+
+// Field numbers from google/protobuf/descriptor.proto.
+const (
+	fileDescriptorPackage     = 2
+	fileDescriptorMessageType = 4
+	fileDescriptorExtension   = 7
+	descriptorName            = 1
+	descriptorField           = 2
+	descriptorNestedType      = 3
+	descriptorExtension       = 6
+	fieldDescriptorName       = 1
+	fieldDescriptorExtendee   = 2
+	fieldDescriptorNumber     = 3
+	fieldDescriptorOptions    = 8
+)
+
+// decodeFile decodes a serialized google.protobuf.FileDescriptorProto.
+// Nested messages are flattened.
+func decodeFile(b []byte) (*protoFile, error) {
+	records, err := decodeRecords(b)
+	if err != nil {
+		return nil, err
+	}
+	f := &protoFile{}
+	for _, r := range records {
+		if r.number == fileDescriptorPackage {
+			f.pkg = string(r.bytes)
+		}
+	}
+	for _, r := range records {
+		switch r.number {
+		case fileDescriptorMessageType:
+			if err := f.decodeMessage(r.bytes, ""); err != nil {
+				return nil, err
+			}
+		case fileDescriptorExtension:
+			ext, err := decodeField(r.bytes)
+			if err != nil {
+				return nil, err
+			}
+			ext.name = qualify(f.pkg, ext.name)
+			f.extensions = append(f.extensions, ext)
+		}
+	}
+	return f, nil
+}
+
+// decodeMessage decodes a serialized google.protobuf.DescriptorProto,
+// declared in the message with the given name, if any.
+func (f *protoFile) decodeMessage(b []byte, parent string) error {
+	records, err := decodeRecords(b)
+	if err != nil {
+		return err
+	}
+	m := &protoMessage{}
+	for _, r := range records {
+		if r.number == descriptorName {
+			m.name = qualify(parent, string(r.bytes))
+		}
+	}
+	f.messages = append(f.messages, m)
+	for _, r := range records {
+		switch r.number {
+		case descriptorField:
+			pf, err := decodeField(r.bytes)
+			if err != nil {
+				return err
+			}
+			m.fields = append(m.fields, pf)
+		case descriptorNestedType:
+			if err := f.decodeMessage(r.bytes, m.name); err != nil {
+				return err
+			}
+		case descriptorExtension:
+			ext, err := decodeField(r.bytes)
+			if err != nil {
+				return err
+			}
+			ext.name = qualify(qualify(f.pkg, m.name), ext.name)
+			f.extensions = append(f.extensions, ext)
+		}
+	}
+	return nil
+}
+
+// decodeField decodes a serialized google.protobuf.FieldDescriptorProto.
+func decodeField(b []byte) (protoField, error) {
+	records, err := decodeRecords(b)
+	if err != nil {
+		return protoField{}, err
+	}
+	var pf protoField
+	for _, r := range records {
+		switch r.number {
+		case fieldDescriptorName:
+			pf.name = string(r.bytes)
+		case fieldDescriptorExtendee:
+			pf.extendee = string(r.bytes)
+		case fieldDescriptorNumber:
+			pf.number = int32(r.value)
+		case fieldDescriptorOptions:
+			pf.options = r.bytes
+		}
+	}
+	return pf, nil
+}
+
+func qualify(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// Wire types of the protobuf encoding.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errMalformed = errors.New("malformed protobuf message")
+
+// A protoRecord is an encoded field of a protobuf message.
+// Numeric values are held in value, and length-delimited values in bytes.
+type protoRecord struct {
+	number int32
+	value  uint64
+	bytes  []byte
+}
+
+// decodeRecords decodes the fields of a message serialized in the protobuf wire format.
+func decodeRecords(b []byte) ([]protoRecord, error) {
+	var records []protoRecord
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errMalformed
+		}
+		b = b[n:]
+		r := protoRecord{number: int32(tag >> 3)}
+		switch wireType := tag & 7; wireType {
+		case wireVarint:
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return nil, errMalformed
+			}
+			r.value, b = v, b[n:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return nil, errMalformed
+			}
+			r.value, b = binary.LittleEndian.Uint64(b), b[8:]
+		case wireFixed32:
+			if len(b) < 4 {
+				return nil, errMalformed
+			}
+			r.value, b = uint64(binary.LittleEndian.Uint32(b)), b[4:]
+		case wireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || l > uint64(len(b)-n) {
+				return nil, errMalformed
+			}
+			r.bytes, b = b[n:n+int(l)], b[n+int(l):]
+		default:
+			return nil, fmt.Errorf("unsupported protobuf wire type %d", wireType)
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
@@ -23,6 +23,13 @@ type Person struct {
 	name                     string      `some_key:"non_secret"`
 	spaceAfterFinalQuote     string      `key:"value" `
 	someNotTaggedField       int
+	email                    string `datapolicy:"pii.email"`  // want email:"tagged field"
+	apiKey                   string `datapolicy:"credential"` // want apiKey:"tagged field"
+	region                   string `datapolicy:"location"`
+	displayName              string `levee:"-"`           // want displayName:"safe field"
+	nickname                 string `levee:"safe"`        // want nickname:"safe field"
+	avatar                   string `example:"public"`    // want avatar:"safe field"
+	greeting                 string `levee:"source,safe"` // want greeting:"safe field"
 }
 
 type Nester struct {
@@ -0,0 +1,185 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package formatter implements identification of redacting formatting methods.
+// A formatting method is a String, GoString, Error or Format method that the fmt
+// package uses to format a value. It is redacting if it never produces data
+// derived from the source fields of its receiver.
+package formatter
+
+import (
+	"go/types"
+	"reflect"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
+	"github.com/google/go-flow-levee/internal/pkg/propagation"
+	"github.com/google/go-flow-levee/internal/pkg/sourcetype"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// ResultType is the set of formatting methods that are known to be redacting.
+type ResultType = propagation.RedactingFormatters
+
+type isRedactingFormatter struct{}
+
+func (i isRedactingFormatter) AFact() {}
+
+func (i isRedactingFormatter) String() string {
+	return "redacting formatter"
+}
+
+var Analyzer = &analysis.Analyzer{
+	Name: "formatter",
+	Doc: `This analyzer identifies redacting formatting methods on source types.
+
+A formatting method is a String, GoString, Error or Format method.
+It is redacting if running a propagation from its receiver does not taint
+its return value or, for Format, a call that uses its fmt.State.
+This analysis is only performed if AllowRedactedFormatting is configured.`,
+	Flags:      config.FlagSet,
+	Run:        run,
+	Requires:   []*analysis.Analyzer{buildssa.Analyzer, fieldtags.Analyzer},
+	ResultType: reflect.TypeOf(new(ResultType)).Elem(),
+	FactTypes:  []analysis.Fact{new(isRedactingFormatter)},
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	taggedFields := pass.ResultOf[fieldtags.Analyzer].(fieldtags.ResultType)
+	ssaInput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+
+	conf, err := config.ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+	conf = conf.ForPackage(pass.Pkg)
+
+	redacting := ResultType{}
+	if !conf.AllowRedactedFormatting {
+		return redacting, nil
+	}
+	// include the facts accumulated down the current path in the dependency graph
+	for _, f := range pass.AllObjectFacts() {
+		redacting[f.Object] = true
+	}
+
+	ssaProg := ssaInput.Pkg.Prog
+	for _, mem := range ssaInput.Pkg.Members {
+		ssaType, ok := mem.(*ssa.Type)
+		if !ok || !sourcetype.IsSourceType(conf, taggedFields, ssaType.Type()) {
+			continue
+		}
+		for _, meth := range formattingMethods(pass, ssaType.Type()) {
+			if isRedacting(conf, taggedFields, redacting, ssaProg.FuncValue(meth)) {
+				pass.ExportObjectFact(meth, &isRedactingFormatter{})
+				redacting[meth] = true
+			}
+		}
+	}
+
+	return redacting, nil
+}
+
+// formattingMethods returns the formatting methods declared in the current
+// package on a type or on a pointer to that type.
+func formattingMethods(pass *analysis.Pass, t types.Type) []*types.Func {
+	var methods []*types.Func
+	mset := types.NewMethodSet(types.NewPointer(t))
+	for i := 0; i < mset.Len(); i++ {
+		meth, ok := mset.At(i).Obj().(*types.Func)
+		if !ok || meth.Pkg() != pass.Pkg || !propagation.IsFormattingMethod(meth) {
+			continue
+		}
+		methods = append(methods, meth)
+	}
+	return methods
+}
+
+// isRedacting determines whether a formatting method redacts its receiver, i.e. whether
+// no data derived from its receiver's source fields can reach its return value or,
+// for Format, a call using its fmt.State.
+// As in the fieldpropagator analyzer, propagations start from each access
+// to a source field, as well as from the receiver itself.
+func isRedacting(conf *config.Config, tf fieldtags.ResultType, redacting ResultType, meth *ssa.Function) bool {
+	if meth == nil || len(meth.Blocks) == 0 || len(meth.Params) == 0 {
+		return false
+	}
+	propagations := []propagation.Propagation{propagation.Taint(meth.Params[0], conf, tf, redacting)}
+	for _, b := range meth.Blocks {
+		for _, instr := range b.Instrs {
+			var (
+				txType types.Type
+				field  int
+			)
+			switch t := instr.(type) {
+			case *ssa.Field:
+				txType = t.X.Type()
+				field = t.Field
+			case *ssa.FieldAddr:
+				txType = t.X.Type()
+				field = t.Field
+			default:
+				continue
+			}
+			if sourcetype.IsSourceField(conf, tf, txType, field) {
+				propagations = append(propagations, propagation.Taint(instr.(ssa.Node), conf, tf, redacting))
+			}
+		}
+	}
+
+	var state ssa.Value
+	if len(meth.Params) == 3 {
+		// Format(f fmt.State, verb rune)
+		state = meth.Params[1]
+	}
+	for _, b := range meth.Blocks {
+		for _, instr := range b.Instrs {
+			switch t := instr.(type) {
+			case *ssa.Return:
+			case ssa.CallInstruction:
+				if state == nil || !usesValue(t.Common(), state) {
+					continue
+				}
+			default:
+				continue
+			}
+			for _, prop := range propagations {
+				if prop.IsTainted(instr) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// usesValue determines whether a value is used as the receiver or as an argument
+// in a call, possibly after being converted to another interface type,
+// e.g. when a fmt.State is passed to a function expecting an io.Writer.
+func usesValue(call *ssa.CallCommon, v ssa.Value) bool {
+	if call.IsInvoke() && call.Value == v {
+		return true
+	}
+	for _, a := range call.Args {
+		if ci, ok := a.(*ssa.ChangeInterface); ok {
+			a = ci.X
+		}
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,3 @@
+module formatter_analysistest
+
+go 1.15
@@ -0,0 +1,57 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"fmt"
+	"io"
+)
+
+type Source struct {
+	data string
+	id   int
+}
+
+func (s Source) String() string { // want String:"redacting formatter"
+	return fmt.Sprintf("Source{id: %d}", s.id)
+}
+
+func (s Source) GoString() string {
+	return fmt.Sprintf("Source{data: %q}", s.data)
+}
+
+func (s *Source) Error() string {
+	return "source error: " + s.data
+}
+
+func (s Source) Format(f fmt.State, verb rune) { // want Format:"redacting formatter"
+	io.WriteString(f, "<redacted>")
+}
+
+type LeakyFormat struct {
+	data string
+}
+
+func (l LeakyFormat) Format(f fmt.State, verb rune) {
+	fmt.Fprint(f, l.data)
+}
+
+type NotASource struct {
+	data string
+}
+
+func (n NotASource) String() string {
+	return "<redacted>"
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package levee
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/propagation"
+	"github.com/google/go-flow-levee/internal/pkg/source"
+	"github.com/google/go-flow-levee/internal/pkg/utils"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// usedAllowRules is a package fact that records the Allow rules
+// that matched a flow in a package or in one of its dependencies.
+// Rules are sorted, so that the fact is encoded deterministically.
+type usedAllowRules struct {
+	Rules []string
+}
+
+func (u *usedAllowRules) AFact() {}
+
+func (u *usedAllowRules) String() string {
+	return fmt.Sprintf("used allow rules: %d", len(u.Rules))
+}
+
+// allowRuleKey identifies an Allow rule across packages and profiles.
+// Rules are identified by the profile they belong to and by their position.
+func allowRuleKey(profileName string, rule int) string {
+	return fmt.Sprintf("%s/%d", profileName, rule)
+}
+
+// describeFlow describes a source reaching a sink, so that it can be matched by Allow rules.
+func describeFlow(src *source.Source, sink ssa.Instruction) config.Flow {
+	var f config.Flow
+	switch n := src.Node.(type) {
+	case *ssa.Field:
+		f.SourcePath, f.SourceType, f.SourceField = utils.DecomposeField(n.X.Type(), n.Field)
+	case *ssa.FieldAddr:
+		f.SourcePath, f.SourceType, f.SourceField = utils.DecomposeField(n.X.Type(), n.Field)
+	case ssa.Value:
+		f.SourcePath, f.SourceType = utils.DecomposeType(utils.Dereference(n.Type()))
+	}
+	if call, ok := sink.(ssa.CallInstruction); ok && call.Common().StaticCallee() != nil {
+		f.SinkPath, f.SinkReceiver, f.SinkName = utils.DecomposeFunction(call.Common().StaticCallee())
+	} else {
+		f.SinkName = "panic"
+	}
+	f.FunctionPath, f.FunctionReceiver, f.FunctionName = utils.DecomposeFunction(sink.Parent())
+	return f
+}
+
+// allowingRule returns the index of the Allow rule that matches a source reaching a sink,
+// or -1 if no rule matches. A source that is not a field access, e.g. a parameter
+// of a source type, is matched by a rule on one of its fields if the sink is only
+// reached through accesses to fields that the rule allows.
+func allowingRule(conf *config.Config, src *source.Source, prop propagation.Propagation, sink ssa.Instruction) int {
+	flow := describeFlow(src, sink)
+	if rule := conf.AllowingRule(flow); rule >= 0 || flow.SourceField != "" {
+		return rule
+	}
+	allowing := -1
+	for _, access := range prop.SourceFieldAccesses() {
+		if !isTaintedSink(conf, prop.From(access), sink) {
+			continue
+		}
+		fieldFlow := flow
+		switch n := access.(type) {
+		case *ssa.Field:
+			fieldFlow.SourcePath, fieldFlow.SourceType, fieldFlow.SourceField = utils.DecomposeField(n.X.Type(), n.Field)
+		case *ssa.FieldAddr:
+			fieldFlow.SourcePath, fieldFlow.SourceType, fieldFlow.SourceField = utils.DecomposeField(n.X.Type(), n.Field)
+		}
+		rule := conf.AllowingRule(fieldFlow)
+		if rule < 0 {
+			return -1
+		}
+		if allowing < 0 {
+			allowing = rule
+		}
+	}
+	return allowing
+}
+
+// reportUnusedAllowRules reports the Allow rules that did not match any flow,
+// so that the rules do not outlive the flows they were written for.
+// Since packages are analyzed separately, the rules that were used are
+// accumulated across dependencies using facts.
+// A rule that is scoped to a package can only match flows in that package,
+// so it is reported there. Other rules may match flows in any package,
+// so they are reported in main packages, once the whole program has been analyzed.
+func reportUnusedAllowRules(pass *analysis.Pass, confs []*config.Config, used map[string]bool) {
+	for _, imp := range pass.Pkg.Imports() {
+		var fact usedAllowRules
+		if pass.ImportPackageFact(imp, &fact) {
+			for _, k := range fact.Rules {
+				used[k] = true
+			}
+		}
+	}
+	if len(used) > 0 {
+		fact := &usedAllowRules{}
+		for k := range used {
+			fact.Rules = append(fact.Rules, k)
+		}
+		sort.Strings(fact.Rules)
+		pass.ExportPackageFact(fact)
+	}
+
+	if len(pass.Files) == 0 {
+		return
+	}
+	for _, conf := range confs {
+		for i, ar := range conf.Allow {
+			if used[allowRuleKey(conf.Name, i)] {
+				continue
+			}
+			pkg, scoped := conf.ScopedPackage(i)
+			if scoped && pkg != pass.Pkg.Path() || !scoped && pass.Pkg.Name() != "main" {
+				continue
+			}
+			rule := fmt.Sprintf("Allow rule %d", i)
+			if conf.Name != "" {
+				rule = fmt.Sprintf("Allow rule %d of profile %q", i, conf.Name)
+			}
+			unused := "did not match any flow"
+			if !scoped {
+				unused += " in this program"
+			}
+			pass.Reportf(pass.Files[0].Package, "%s %s: %s", rule, unused, ar.Reason)
+		}
+	}
+}
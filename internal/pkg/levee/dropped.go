@@ -0,0 +1,125 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package levee
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"github.com/google/go-flow-levee/internal/pkg/propagation"
+	"github.com/google/go-flow-levee/internal/pkg/utils"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// droppedTaintCategory is the category of the diagnostics reporting dropped taint.
+const droppedTaintCategory = "dropped-taint"
+
+// A droppedTaint describes the calls to a function through which taint is dropped,
+// because the function has no summary and is neither a sink nor a sanitizer.
+// It is reported as JSON, and its Package, Receiver and Method fields match
+// the keys of a summary in the configuration, so that the missing summary can be written.
+type droppedTaint struct {
+	// Callee is the full name of the function, as used by the keys of the
+	// summary package's maps, e.g. "(*example.com/lib.Client).Encode".
+	Callee   string
+	Package  string
+	Receiver string
+	Method   string
+	// Calls is the number of calls in the package through which taint is dropped.
+	Calls int
+
+	pos token.Pos
+}
+
+// collectDroppedCalls records the calls through which a Propagation drops taint.
+func collectDroppedCalls(prop propagation.Propagation, dropped map[ssa.CallInstruction]bool) {
+	for _, call := range prop.DroppedCalls() {
+		dropped[call] = true
+	}
+}
+
+// reportDroppedTaint reports the functions through which taint is dropped in a package,
+// once per function, at the first call to it. Calls to function values are not reported,
+// since no summary can be written for them.
+func reportDroppedTaint(pass *analysis.Pass, dropped map[ssa.CallInstruction]bool) {
+	byCallee := map[string]*droppedTaint{}
+	for call := range dropped {
+		d, ok := describeCallee(call.Common())
+		if !ok {
+			continue
+		}
+		pos := call.Pos()
+		if !pos.IsValid() {
+			pos = call.Parent().Pos()
+		}
+		if prev, ok := byCallee[d.Callee]; ok {
+			prev.Calls++
+			if pos < prev.pos {
+				prev.pos = pos
+			}
+			continue
+		}
+		d.Calls = 1
+		d.pos = pos
+		byCallee[d.Callee] = &d
+	}
+
+	var callees []string
+	for c := range byCallee {
+		callees = append(callees, c)
+	}
+	sort.Strings(callees)
+	for _, c := range callees {
+		d := byCallee[c]
+		b, err := json.Marshal(d)
+		if err != nil {
+			continue
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:      d.pos,
+			Category: droppedTaintCategory,
+			Message:  fmt.Sprintf("taint dropped at call to %s: %s", d.Callee, b),
+		})
+	}
+}
+
+// describeCallee describes the function called by a call, if it is known statically
+// or is an interface method.
+func describeCallee(cc *ssa.CallCommon) (droppedTaint, bool) {
+	if cc.IsInvoke() {
+		path, recv := utils.DecomposeType(cc.Value.Type())
+		return droppedTaint{
+			Callee:   fmt.Sprintf("(%s).%s", types.TypeString(cc.Value.Type(), nil), cc.Method.Name()),
+			Package:  path,
+			Receiver: recv,
+			Method:   cc.Method.Name(),
+		}, true
+	}
+	callee := cc.StaticCallee()
+	if callee == nil {
+		return droppedTaint{}, false
+	}
+	path, recv, name := utils.DecomposeFunction(callee)
+	return droppedTaint{
+		Callee:   callee.RelString(nil),
+		Package:  path,
+		Receiver: recv,
+		Method:   name,
+	}, true
+}
@@ -0,0 +1,147 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package levee
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
+	"github.com/google/go-flow-levee/internal/pkg/propagation"
+	"github.com/google/go-flow-levee/internal/pkg/source"
+	"github.com/google/go-flow-levee/internal/pkg/sourcetype"
+	"github.com/google/go-flow-levee/internal/pkg/suppression"
+	"github.com/google/go-flow-levee/internal/pkg/utils"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// An escape is a value that leaves an exported function and reaches its callers,
+// either by being returned or by being stored into a pointer parameter.
+// Escapes are soft sinks: callers may not know that the value is sensitive.
+type escape struct {
+	instr ssa.Instruction
+	value ssa.Value
+	// param is the parameter that the value is stored into, if any.
+	param *ssa.Parameter
+	// description describes how the value escapes, e.g. "returned by Fetch".
+	description string
+}
+
+// reportsEscapes determines whether the sources escaping through the exported functions
+// of a package are reported. Main packages cannot be imported, so nothing escapes from them.
+func reportsEscapes(conf *config.Config, pkg *types.Package) bool {
+	return conf.ReportEscapes && pkg.Name() != "main" && !conf.IsSourceHandlingPackage(pkg.Path())
+}
+
+// isExportedAPI determines whether a function can be called from other packages,
+// i.e. whether it is an exported function or an exported method of an exported type.
+func isExportedAPI(fn *ssa.Function) bool {
+	obj := fn.Object()
+	if obj == nil || !obj.Exported() {
+		return false
+	}
+	recv := fn.Signature.Recv()
+	if recv == nil {
+		return true
+	}
+	named, ok := utils.Dereference(recv.Type()).(*types.Named)
+	return ok && named.Obj().Exported()
+}
+
+// escapes lists the values that an instruction hands to the callers of a function.
+func escapes(fn *ssa.Function, instr ssa.Instruction) []escape {
+	name := fn.RelString(fn.Pkg.Pkg)
+	switch v := instr.(type) {
+	case *ssa.Return:
+		var es []escape
+		for _, r := range v.Results {
+			es = append(es, escape{instr: v, value: r, description: "returned by " + name})
+		}
+		return es
+	case *ssa.Store:
+		if p := pointerParameter(fn, v.Addr); p != nil {
+			return []escape{{instr: v, value: v.Val, param: p, description: fmt.Sprintf("stored into parameter %s of %s", p.Name(), name)}}
+		}
+	}
+	return nil
+}
+
+// pointerParameter returns the parameter that an address is derived from, if any,
+// e.g. dst for both "*dst = x" and "dst.Field = x".
+// Stores into the receiver of a method are not considered, since they update
+// the state of the receiver rather than handing a value to the caller.
+func pointerParameter(fn *ssa.Function, addr ssa.Value) *ssa.Parameter {
+	for {
+		switch a := addr.(type) {
+		case *ssa.FieldAddr:
+			addr = a.X
+		case *ssa.IndexAddr:
+			addr = a.X
+		case *ssa.UnOp:
+			if a.Op != token.MUL {
+				return nil
+			}
+			addr = a.X
+		case *ssa.Parameter:
+			if fn.Signature.Recv() != nil && a == fn.Params[0] {
+				return nil
+			}
+			return a
+		default:
+			return nil
+		}
+	}
+}
+
+// reportSourcesEscaping reports the sources that escape through an exported function.
+// Values whose type is a source type are not reported, since callers can tell that they are sensitive.
+// Allow rules describe flows to sinks, so they do not apply to escapes.
+func reportSourcesEscaping(conf *config.Config, prof profile, pass *analysis.Pass, taggedFields fieldtags.ResultType, suppressedNodes suppression.ResultType, propagations map[*source.Source]propagation.Propagation, e escape) {
+	if sourcetype.IsSourceType(conf, taggedFields, e.value.Type()) {
+		return
+	}
+	if e.param != nil && sourcetype.IsSourceType(conf, taggedFields, e.param.Type()) {
+		return
+	}
+	pos := e.instr.Pos()
+	if !pos.IsValid() {
+		pos = e.instr.Parent().Pos()
+	}
+	if isSuppressed(pos, suppressedNodes, pass) {
+		return
+	}
+
+	var escaped []*source.Source
+	for src, prop := range propagations {
+		if prop.IsTaintedOperand(e.instr, e.value) {
+			escaped = append(escaped, src)
+		}
+	}
+	escaped = sortSources(pass.Fset, escaped)
+
+	message := prof.escapeMessage + ": " + e.description
+	if reportEachSource {
+		for _, src := range escaped {
+			reportAt(conf, prof, pass, pos, message, []*source.Source{src})
+		}
+		return
+	}
+	if len(escaped) > 0 {
+		reportAt(conf, prof, pass, pos, message, escaped)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package levee
+
+import (
+	"sync"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
+	"github.com/google/go-flow-levee/internal/pkg/formatter"
+	"github.com/google/go-flow-levee/internal/pkg/source"
+	"github.com/google/go-flow-levee/internal/pkg/suppression"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+)
+
+var IntegrityAnalyzer = &analysis.Analyzer{
+	Name: "integrity",
+	Run:  runIntegrity,
+	Doc: `reports untrusted input reaching injection sinks
+
+Untrusted input, such as HTTP requests and command-line arguments,
+is reported when it reaches a SQL query, a command, or a file path
+without being validated. This analyzer uses a built-in configuration.`,
+	Requires: []*analysis.Analyzer{
+		buildssa.Analyzer,
+		suppression.Analyzer,
+	},
+}
+
+// integrity is the profile used by the integrity Analyzer,
+// which reports untrusted input reaching an injection sink.
+var integrity = profile{
+	category:       "integrity",
+	message:        "untrusted input has reached an injection sink",
+	contextMessage: "a context holding untrusted input has reached an injection sink",
+	escapeMessage:  "untrusted input escapes through an exported function",
+}
+
+// integrityConfig is the built-in configuration of the integrity Analyzer.
+// Sources are untrusted inputs, sinks are the arguments of calls that
+// are vulnerable to SQL, command or path injection, and sanitizers are
+// functions that validate their input.
+const integrityConfig = `
+SourceParameters:
+  - Signature: "func(net/http.ResponseWriter, *net/http.Request)"
+    Parameter: 1
+SourceFunctions:
+  - Package: "net/http"
+    Receiver: "*Request"
+    MethodRE: "^(Cookie|Cookies|FormFile|FormValue|PostFormValue|Referer|UserAgent)$"
+  - Package: "net/url"
+    Receiver: "*URL"
+    MethodRE: "^(EscapedPath|Query)$"
+  - Package: "flag"
+    MethodRE: "^Args?$"
+SourceVariables:
+  - Package: "os"
+    Name: "Args"
+Sinks:
+  # Only the query is considered: query parameters are not subject to injection.
+  - Package: "database/sql"
+    ReceiverRE: "^\\*(Conn|DB|Tx)$"
+    MethodRE: "^(Exec|Prepare|Query|QueryRow)$"
+    Argument: 0
+  - Package: "database/sql"
+    ReceiverRE: "^\\*(Conn|DB|Tx)$"
+    MethodRE: "^(Exec|Prepare|Query|QueryRow)Context$"
+    Argument: 1
+  - Package: "os/exec"
+    Receiver: ""
+    MethodRE: "^Command(Context)?$"
+  - Package: "path/filepath"
+    Receiver: ""
+    Method: "Join"
+  - Package: "os"
+    Receiver: ""
+    MethodRE: "^(Create|Mkdir|MkdirAll|Open|OpenFile|ReadFile|Remove|RemoveAll|WriteFile)$"
+    Argument: 0
+  - Package: "io/ioutil"
+    Receiver: ""
+    MethodRE: "^(ReadDir|ReadFile|WriteFile)$"
+    Argument: 0
+Sanitizers:
+  - Package: "strconv"
+    MethodRE: "^(Atoi|ParseBool|ParseFloat|ParseInt|ParseUint)$"
+  - Package: "path/filepath"
+    Method: "Base"
+  - Package: "regexp"
+    Receiver: "*Regexp"
+    MethodRE: "^Match(String)?$"
+AllowPanicOnTaintedValues: true
+`
+
+var (
+	integrityConfigOnce sync.Once
+	integrityConf       *config.Config
+	integrityConfErr    error
+)
+
+func runIntegrity(pass *analysis.Pass) (interface{}, error) {
+	integrityConfigOnce.Do(func() {
+		integrityConf, integrityConfErr = config.Parse([]byte(integrityConfig))
+	})
+	if integrityConfErr != nil {
+		return nil, integrityConfErr
+	}
+	ssaInput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	suppressedNodes := pass.ResultOf[suppression.Analyzer].(suppression.ResultType)
+
+	funcSources := source.Identify(integrityConf, ssaInput, fieldtags.ResultType{})
+	reportFlows(pass, integrityConf, integrity, funcSources, fieldtags.ResultType{}, formatter.ResultType{}, suppressedNodes, map[string]bool{}, nil)
+	return nil, nil
+}
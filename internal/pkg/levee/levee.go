@@ -18,29 +18,75 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
+	"sort"
 	"strings"
 
 	"github.com/google/go-flow-levee/internal/pkg/config"
 	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
+	"github.com/google/go-flow-levee/internal/pkg/formatter"
 	"github.com/google/go-flow-levee/internal/pkg/propagation"
 	"github.com/google/go-flow-levee/internal/pkg/source"
 	"github.com/google/go-flow-levee/internal/pkg/suppression"
 	"github.com/google/go-flow-levee/internal/pkg/utils"
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/ssa"
 )
 
+// reportEachSource makes the Analyzer report each source reaching a sink separately,
+// instead of listing all of them in a single report.
+var reportEachSource = false
+
+// reportDroppedTaintFlag makes the Analyzer report the calls through which taint is dropped,
+// because their function has no summary, aggregated by function.
+var reportDroppedTaintFlag = false
+
+func init() {
+	Analyzer.Flags.BoolVar(&reportEachSource, "reportEachSource", false,
+		`report each source reaching a sink separately, instead of listing all of them in a single report.`)
+	Analyzer.Flags.BoolVar(&reportDroppedTaintFlag, "reportDroppedTaint", false,
+		`report the functions without a summary through which taint is dropped, with the number of calls to each of them.`)
+}
+
 var Analyzer = &analysis.Analyzer{
 	Name:  "levee",
 	Run:   run,
 	Flags: config.FlagSet,
 	Doc:   "reports attempts to source data to sinks",
 	Requires: []*analysis.Analyzer{
+		buildssa.Analyzer,
 		fieldtags.Analyzer,
+		formatter.Analyzer,
 		source.Analyzer,
 		suppression.Analyzer,
 	},
+	FactTypes: []analysis.Fact{new(usedAllowRules)},
+}
+
+// A profile determines how findings are reported.
+type profile struct {
+	// name is the name of the configuration profile that produced
+	// the findings, if any. It is included in the diagnostics.
+	name string
+	// category is the category of the diagnostics.
+	category string
+	// message describes a source reaching a sink.
+	message string
+	// contextMessage describes a context holding a source reaching a sink.
+	contextMessage string
+	// escapeMessage describes a source escaping through an exported function.
+	escapeMessage string
+}
+
+// confidentiality is the profile used by the levee Analyzer,
+// which reports sensitive data reaching a sink.
+var confidentiality = profile{
+	category:       "confidentiality",
+	message:        "a source has reached a sink",
+	contextMessage: "a context holding a source has reached a sink",
+	escapeMessage:  "a source escapes through an exported function",
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
@@ -48,43 +94,220 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	conf = conf.ForPackage(pass.Pkg)
 	funcSources := pass.ResultOf[source.Analyzer].(source.ResultType)
 	taggedFields := pass.ResultOf[fieldtags.Analyzer].(fieldtags.ResultType)
+	formatters := pass.ResultOf[formatter.Analyzer].(formatter.ResultType)
 	suppressedNodes := pass.ResultOf[suppression.Analyzer].(suppression.ResultType)
 
+	usedRules := map[string]bool{}
+	var dropped map[ssa.CallInstruction]bool
+	if reportDroppedTaintFlag {
+		dropped = map[ssa.CallInstruction]bool{}
+	}
+	reportFlows(pass, conf, confidentiality, funcSources.For(conf), taggedFields, formatters, suppressedNodes, usedRules, dropped)
+
+	// Each profile is evaluated independently, reusing the SSA and field tags.
+	// Profiles cannot allow redacted formatting, so no formatters are used for them.
+	confs := []*config.Config{conf}
+	for _, p := range conf.Profiles {
+		p = p.ForPackage(pass.Pkg)
+		prof := confidentiality
+		prof.name = p.Name
+		prof.category = p.Name
+		reportFlows(pass, p, prof, funcSources.For(p), taggedFields, formatter.ResultType{}, suppressedNodes, usedRules, dropped)
+		confs = append(confs, p)
+	}
+
+	reportUnusedAllowRules(pass, confs, usedRules)
+	if dropped != nil {
+		reportDroppedTaint(pass, dropped)
+	}
+	return nil, nil
+}
+
+// reportFlows reports the sources that reach a sink in each function,
+// except for the functions declared in excluded files.
+// If the Config enables it, the sources escaping through exported functions are also reported.
+// The Allow rules that waive a flow are recorded in usedRules.
+// If dropped is not nil, the calls through which taint is dropped are recorded in it.
+func reportFlows(pass *analysis.Pass, conf *config.Config, prof profile, funcSources source.FuncSources, taggedFields fieldtags.ResultType, formatters formatter.ResultType, suppressedNodes suppression.ResultType, usedRules map[string]bool, dropped map[ssa.CallInstruction]bool) {
+	excluded := conf.ExcludedFiles(pass.Fset, pass.Files)
 	for fn, sources := range funcSources {
+		if excluded[pass.Fset.File(fn.Pos())] {
+			continue
+		}
 		propagations := make(map[*source.Source]propagation.Propagation, len(sources))
 		for _, s := range sources {
-			propagations[s] = propagation.Taint(s.Node, conf, taggedFields)
+			propagations[s] = propagation.Taint(s.Node, conf, taggedFields, formatters)
+			if dropped != nil {
+				collectDroppedCalls(propagations[s], dropped)
+			}
 		}
+		checkEscapes := reportsEscapes(conf, pass.Pkg) && isExportedAPI(fn)
 
 		for _, b := range fn.Blocks {
 			for _, instr := range b.Instrs {
 				switch v := instr.(type) {
-				case *ssa.Call:
-					if callee := v.Call.StaticCallee(); callee != nil && conf.IsSink(utils.DecomposeFunction(callee)) {
-						reportSourcesReachingSink(conf, pass, suppressedNodes, propagations, instr)
+				// Call, Defer and Go instructions are all CallInstructions.
+				// For Defer and Go, the arguments are evaluated when the instruction
+				// is executed, so taint is checked at that point.
+				case ssa.CallInstruction:
+					if isPanic(v) && !conf.AllowPanicOnTaintedValues {
+						reportSourcesReachingSink(conf, prof, pass, suppressedNodes, propagations, instr, usedRules)
+						continue
+					}
+					if callee := v.Common().StaticCallee(); callee != nil && conf.IsSink(utils.DecomposeFunction(callee)) {
+						reportSourcesReachingSink(conf, prof, pass, suppressedNodes, propagations, instr, usedRules)
 					}
 				case *ssa.Panic:
 					if conf.AllowPanicOnTaintedValues {
 						continue
 					}
-					reportSourcesReachingSink(conf, pass, suppressedNodes, propagations, instr)
+					reportSourcesReachingSink(conf, prof, pass, suppressedNodes, propagations, instr, usedRules)
+				case *ssa.Return, *ssa.Store:
+					if !checkEscapes {
+						continue
+					}
+					for _, e := range escapes(fn, instr) {
+						reportSourcesEscaping(conf, prof, pass, taggedFields, suppressedNodes, propagations, e)
+					}
 				}
 			}
 		}
 	}
+}
 
-	return nil, nil
+// isPanic determines whether a call instruction is a deferred or spawned
+// call to the panic builtin, e.g. "defer panic(x)".
+// Direct calls to panic are represented by the Panic instruction.
+func isPanic(call ssa.CallInstruction) bool {
+	builtin, ok := call.Common().Value.(*ssa.Builtin)
+	return ok && builtin.Name() == "panic"
 }
 
-func reportSourcesReachingSink(conf *config.Config, pass *analysis.Pass, suppressedNodes suppression.ResultType, propagations map[*source.Source]propagation.Propagation, sink ssa.Instruction) {
+// reportSourcesReachingSink reports the sources reaching a sink, unless their flows are allowed.
+// All sources are examined, so that every Allow rule that matches a flow is recorded as used.
+// The sources are listed in a single report, ordered by position, unless reportEachSource is set.
+func reportSourcesReachingSink(conf *config.Config, prof profile, pass *analysis.Pass, suppressedNodes suppression.ResultType, propagations map[*source.Source]propagation.Propagation, sink ssa.Instruction, usedRules map[string]bool) {
+	var reached []*source.Source
+	viaContext := map[*source.Source]bool{}
 	for src, prop := range propagations {
-		if prop.IsTainted(sink) && !isSuppressed(sink.Pos(), suppressedNodes, pass) {
-			report(conf, pass, src, sink.(ssa.Node))
-			break
+		if !isTaintedSink(conf, prop, sink) || isSuppressed(sink.Pos(), suppressedNodes, pass) {
+			continue
 		}
+		if rule := allowingRule(conf, src, prop, sink); rule >= 0 {
+			usedRules[allowRuleKey(conf.Name, rule)] = true
+			continue
+		}
+		reached = append(reached, src)
+		viaContext[src] = isTaintedByContextOnly(conf, prop, sink)
+	}
+	reached = sortSources(pass.Fset, reached)
+
+	if reportEachSource {
+		for _, src := range reached {
+			report(conf, prof, pass, []*source.Source{src}, sink.(ssa.Node), viaContext[src])
+		}
+		return
+	}
+	if len(reached) == 0 {
+		return
 	}
+	// The context message is only used if none of the sources reach the sink directly.
+	onlyViaContext := true
+	for _, src := range reached {
+		onlyViaContext = onlyViaContext && viaContext[src]
+	}
+	report(conf, prof, pass, reached, sink.(ssa.Node), onlyViaContext)
+}
+
+// sortSources orders sources by position, then by description, so that reports are deterministic.
+// Sources that have the same position and description are only listed once.
+func sortSources(fset *token.FileSet, sources []*source.Source) []*source.Source {
+	less := func(a, b *source.Source) bool {
+		pa, pb := fset.Position(a.Pos()), fset.Position(b.Pos())
+		switch {
+		case pa.Filename != pb.Filename:
+			return pa.Filename < pb.Filename
+		case pa.Line != pb.Line:
+			return pa.Line < pb.Line
+		case pa.Column != pb.Column:
+			return pa.Column < pb.Column
+		}
+		return describeSource(a) < describeSource(b)
+	}
+	sort.Slice(sources, func(i, j int) bool { return less(sources[i], sources[j]) })
+
+	var distinct []*source.Source
+	for i, src := range sources {
+		if i > 0 && !less(sources[i-1], src) {
+			continue
+		}
+		distinct = append(distinct, src)
+	}
+	return distinct
+}
+
+// describeSource describes the type of a source, e.g. "example.com/core.Source",
+// or the field that it accesses, e.g. "example.com/core.Source.Password".
+func describeSource(src *source.Source) string {
+	switch n := src.Node.(type) {
+	case *ssa.Field:
+		return describeField(n.X.Type(), n.Field)
+	case *ssa.FieldAddr:
+		return describeField(n.X.Type(), n.Field)
+	case ssa.Value:
+		return types.TypeString(utils.Dereference(n.Type()), nil)
+	}
+	return src.Node.String()
+}
+
+func describeField(t types.Type, field int) string {
+	deref := utils.Dereference(t)
+	return types.TypeString(deref, nil) + "." + deref.Underlying().(*types.Struct).Field(field).Name()
+}
+
+// isTaintedSink determines whether a sink is tainted by a Propagation.
+// A call is tainted if one of its arguments or its receiver is tainted, or if the argument
+// that the sink is configured with is tainted. See includesReceiver for the receiver.
+func isTaintedSink(conf *config.Config, prop propagation.Propagation, sink ssa.Instruction) bool {
+	call, ok := sink.(ssa.CallInstruction)
+	if !ok {
+		return prop.IsTainted(sink)
+	}
+	if callee := call.Common().StaticCallee(); callee != nil {
+		if arg, ok := conf.SinkArgument(utils.DecomposeFunction(callee)); ok {
+			return prop.IsTaintedArg(call, arg)
+		}
+	}
+	return prop.IsTaintedCall(call, includesReceiver(conf, prop, call))
+}
+
+// isTaintedByContextOnly determines whether a sink is only tainted because
+// it receives a context holding a tainted value, e.g. core.Sink(ctx).
+func isTaintedByContextOnly(conf *config.Config, prop propagation.Propagation, sink ssa.Instruction) bool {
+	call, ok := sink.(ssa.CallInstruction)
+	return ok && prop.IsTaintedByContextOnly(call, includesReceiver(conf, prop, call))
+}
+
+// includesReceiver determines whether the receiver of a sink is considered.
+// A receiver that is built by attaching arguments to a value, e.g. the logger
+// returned by logger.With("key", value), only carries the taint of those arguments
+// to the sink if the sink is configured with a sensitive receiver.
+func includesReceiver(conf *config.Config, prop propagation.Propagation, call ssa.CallInstruction) bool {
+	callee := call.Common().StaticCallee()
+	if callee != nil && conf.IsSinkWithSensitiveReceiver(utils.DecomposeFunction(callee)) {
+		return true
+	}
+	cc := call.Common()
+	switch {
+	case cc.IsInvoke():
+		return !prop.IsBuilt(cc.Value)
+	case cc.Signature().Recv() != nil && len(cc.Args) > 0:
+		return !prop.IsBuilt(cc.Args[0])
+	}
+	return true
 }
 
 func isSuppressed(pos token.Pos, suppressedNodes suppression.ResultType, pass *analysis.Pass) bool {
@@ -98,41 +321,84 @@ func isSuppressed(pos token.Pos, suppressedNodes suppression.ResultType, pass *a
 		if len(path) < 2 {
 			return false
 		}
+		// Deferred and spawned calls are reported at the position of the
+		// defer or go keyword, so path[0] holds the statement itself.
+		// Prepend the call, so that it is handled like any other call.
+		switch s := path[0].(type) {
+		case *ast.DeferStmt:
+			path = append([]ast.Node{s.Call}, path...)
+		case *ast.GoStmt:
+			path = append([]ast.Node{s.Call}, path...)
+		}
 		// Given the position of a call, path[0] holds the ast.CallExpr and
-		// path[1] holds the ast.ExprStmt. A suppressing comment may be associated
-		// with the name of the function being called (Ident, SelectorExpr), with the
-		// call itself (CallExpr), or with the entire expression (ExprStmt).
-		if ce, ok := path[0].(*ast.CallExpr); ok {
-			switch t := ce.Fun.(type) {
-			case *ast.Ident:
-				/*
-					Sink( // levee.DoNotReport
-				*/
-				if suppressedNodes.IsSuppressed(t) {
+		// path[1] holds the enclosing statement, e.g. an ast.ExprStmt or ast.DeferStmt.
+		// A suppressing comment may be associated with the name of the function
+		// being called (Ident, SelectorExpr), with the call itself (CallExpr),
+		// or with the entire statement.
+		ce, ok := path[0].(*ast.CallExpr)
+		if !ok {
+			// Escapes are reported at return statements and assignments.
+			// A suppressing comment may be associated with any node up to the enclosing statement.
+			/*
+				return c.Password // levee.DoNotReport
+			*/
+			for _, n := range path {
+				if suppressedNodes.IsSuppressed(n) {
 					return true
 				}
-			case *ast.SelectorExpr:
-				/*
-					core.Sink( // levee.DoNotReport
-				*/
-				if suppressedNodes.IsSuppressed(t.Sel) {
-					return true
+				if _, ok := n.(ast.Stmt); ok {
+					return false
 				}
 			}
-		} else {
-			fmt.Printf("unexpected node received: %v (type %T); please report this issue\n", path[0], path[0])
+			return false
+		}
+		switch t := ce.Fun.(type) {
+		case *ast.Ident:
+			/*
+				Sink( // levee.DoNotReport
+			*/
+			if suppressedNodes.IsSuppressed(t) {
+				return true
+			}
+		case *ast.SelectorExpr:
+			/*
+				core.Sink( // levee.DoNotReport
+			*/
+			if suppressedNodes.IsSuppressed(t.Sel) {
+				return true
+			}
 		}
 		return suppressedNodes.IsSuppressed(path[0]) || suppressedNodes.IsSuppressed(path[1])
 	}
 	return false
 }
 
-func report(conf *config.Config, pass *analysis.Pass, source *source.Source, sink ssa.Node) {
+// report reports sources reaching a sink. If the sink only receives a context
+// holding the sources, the report says so, since the context itself is being used.
+func report(conf *config.Config, prof profile, pass *analysis.Pass, sources []*source.Source, sink ssa.Node, viaContext bool) {
+	message := prof.message
+	if viaContext {
+		message = prof.contextMessage
+	}
+	reportAt(conf, prof, pass, sink.Pos(), message, sources)
+}
+
+// reportAt reports a message at a position, listing the sources that it concerns.
+func reportAt(conf *config.Config, prof profile, pass *analysis.Pass, pos token.Pos, message string, sources []*source.Source) {
 	var b strings.Builder
-	b.WriteString("a source has reached a sink")
-	fmt.Fprintf(&b, "\n source: %v", pass.Fset.Position(source.Pos()))
+	b.WriteString(message)
+	for _, src := range sources {
+		fmt.Fprintf(&b, "\n source: %s at %v", describeSource(src), pass.Fset.Position(src.Pos()))
+	}
+	if prof.name != "" {
+		fmt.Fprintf(&b, "\n profile: %v", prof.name)
+	}
 	if conf.ReportMessage != "" {
 		fmt.Fprintf(&b, "\n %v", conf.ReportMessage)
 	}
-	pass.Reportf(sink.Pos(), b.String())
+	pass.Report(analysis.Diagnostic{
+		Pos:      pos,
+		Category: prof.category,
+		Message:  b.String(),
+	})
 }
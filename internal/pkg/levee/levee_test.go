@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/google/go-flow-levee/internal/pkg/debug"
+	"github.com/google/go-flow-levee/internal/pkg/propagation/summary"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -61,3 +62,144 @@ func TestFormattingWithoutCustomReportMessage(t *testing.T) {
 	}
 	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/custom.message.com/nocustom")
 }
+
+func TestLeveeDoesNotCreateReportsForRedactedFormattingIfAllowed(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/redacted-formatting-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/redactedformatting.com/...")
+}
+
+func TestStructuredLoggingAttributes(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/structured-logging-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/structuredlogging.com/...")
+}
+
+func TestIntegrity(t *testing.T) {
+	dataDir := analysistest.TestData()
+	analysistest.Run(t, dataDir, IntegrityAnalyzer, "./src/levee_analysistest/integrity.com/...")
+}
+
+func TestProfiles(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/profiles-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/profiles.com/...")
+}
+
+func TestOverrides(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/overrides-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/overrides.com/...")
+}
+
+func TestSourceInterfaces(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/interfaces-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/interfaces.com/...")
+}
+
+func TestFieldTags(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/fieldtags-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/fieldtags.com/...")
+}
+
+func TestProtoFieldOptions(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/proto-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/proto.com/...")
+}
+
+func TestExclusion(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/exclusion-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/exclusion.com/...")
+}
+
+func TestAllow(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/allow-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/allow.com/...")
+}
+
+func TestAllSourcesReachingASinkAreReported(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/multiple-sources-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/multiplesources.com/tests")
+}
+
+func TestReportEachSource(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/multiple-sources-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	if err := Analyzer.Flags.Set("reportEachSource", "true"); err != nil {
+		t.Error(err)
+	}
+	defer Analyzer.Flags.Set("reportEachSource", "false")
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/multiplesources.com/eachsource")
+}
+
+func TestDroppedTaint(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/summaries-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	if err := Analyzer.Flags.Set("reportDroppedTaint", "true"); err != nil {
+		t.Error(err)
+	}
+	defer Analyzer.Flags.Set("reportDroppedTaint", "false")
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/dropped.com/...")
+}
+
+func TestEscapes(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/escapes-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/escapes.com/...")
+}
+
+func TestConfiguredSummaries(t *testing.T) {
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/summaries-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/summaries.com/...")
+}
+
+func TestExtendedSummaries(t *testing.T) {
+	// Only the values, i.e. the variadic elements at odd positions, propagate taint.
+	key := "levee_analysistest/summaries.com/thirdparty.Values"
+	summary.ExtendedFuncSummaries[key] = summary.Extended{
+		IfTainted:   []summary.Path{summary.At(0).Element(1), summary.At(0).Element(3)},
+		TaintedRets: []summary.Path{summary.At(0)},
+	}
+	defer delete(summary.ExtendedFuncSummaries, key)
+
+	dataDir := analysistest.TestData()
+	if err := Analyzer.Flags.Set("config", dataDir+"/summaries-config.yaml"); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, dataDir, Analyzer, "./src/levee_analysistest/extended.com/...")
+}
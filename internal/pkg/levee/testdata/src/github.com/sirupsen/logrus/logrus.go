@@ -0,0 +1,70 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logrus is a minimal stand-in for github.com/sirupsen/logrus.
+package logrus
+
+type Fields map[string]interface{}
+
+type Logger struct{}
+
+func New() *Logger {
+	return &Logger{}
+}
+
+func (logger *Logger) WithField(key string, value interface{}) *Entry {
+	return &Entry{Data: Fields{key: value}}
+}
+
+func (logger *Logger) WithFields(fields Fields) *Entry {
+	return &Entry{Data: fields}
+}
+
+type Entry struct {
+	Data Fields
+}
+
+func NewEntry(logger *Logger) *Entry {
+	return &Entry{}
+}
+
+func (entry *Entry) WithField(key string, value interface{}) *Entry {
+	return entry
+}
+
+func (entry *Entry) WithFields(fields Fields) *Entry {
+	return entry
+}
+
+func (entry *Entry) WithError(err error) *Entry {
+	return entry
+}
+
+func WithField(key string, value interface{}) *Entry {
+	return &Entry{Data: Fields{key: value}}
+}
+
+func WithFields(fields Fields) *Entry {
+	return &Entry{Data: fields}
+}
+
+func WithError(err error) *Entry {
+	return &Entry{Data: Fields{"error": err}}
+}
+
+func (entry *Entry) Info(args ...interface{}) {}
+
+func (entry *Entry) Warn(args ...interface{}) {}
+
+func (entry *Entry) Print(args ...interface{}) {}
@@ -0,0 +1,74 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zap is a minimal stand-in for go.uber.org/zap.
+package zap
+
+import "fmt"
+
+type Field struct {
+	Key       string
+	String    string
+	Interface interface{}
+}
+
+func String(key string, val string) Field {
+	return Field{Key: key, String: val}
+}
+
+func Stringer(key string, val fmt.Stringer) Field {
+	return Field{Key: key, Interface: val}
+}
+
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Interface: value}
+}
+
+func Int(key string, val int) Field {
+	return Field{Key: key, Interface: val}
+}
+
+func Error(err error) Field {
+	return Field{Key: "error", Interface: err}
+}
+
+type Logger struct {
+	fields []Field
+}
+
+func (l *Logger) With(fields ...Field) *Logger {
+	return &Logger{fields: append(l.fields, fields...)}
+}
+
+func (l *Logger) Named(s string) *Logger {
+	return l
+}
+
+func (l *Logger) Sugar() *SugaredLogger {
+	return &SugaredLogger{base: l}
+}
+
+func (l *Logger) Info(msg string, fields ...Field) {}
+
+func (l *Logger) Error(msg string, fields ...Field) {}
+
+type SugaredLogger struct {
+	base *Logger
+}
+
+func (s *SugaredLogger) With(args ...interface{}) *SugaredLogger {
+	return s
+}
+
+func (s *SugaredLogger) Infow(msg string, keysAndValues ...interface{}) {}
@@ -0,0 +1,35 @@
+// Copyright 2021 Google LLC // want package:"used allow rules: 1"
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth // want "Allow rule 1 did not match any flow: debug logs are not collected"
+
+import (
+	"levee_analysistest/allow.com/core"
+)
+
+func Authenticate(c *core.Credentials) {
+	core.Send(c.Token)
+}
+
+func SendPassword(c *core.Credentials) {
+	core.Send(c.Password) // want "a source has reached a sink"
+}
+
+func SendCredentials(c core.Credentials) {
+	core.Send(c) // want "a source has reached a sink"
+}
+
+func LogToken(c *core.Credentials) {
+	core.Log(c.Token) // want "a source has reached a sink"
+}
@@ -0,0 +1,28 @@
+// Copyright 2021 Google LLC // want package:"used allow rules: 1"
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Rules that are not scoped to a single package are reported in each program
+// that does not reach the flows that they allow, even if another program does.
+package main // want "Allow rule 2 did not match any flow in this program: dumps are only written on the developer's machine" "Allow rule 3 did not match any flow in this program: credentials were uploaded by the legacy client" "Allow rule 4 did not match any flow in this program: tools send credentials to the server"
+
+import (
+	"levee_analysistest/allow.com/auth"
+	"levee_analysistest/allow.com/core"
+)
+
+func main() {
+	c := &core.Credentials{}
+	auth.Authenticate(c)
+	core.Log(c.Token) // want "a source has reached a sink"
+}
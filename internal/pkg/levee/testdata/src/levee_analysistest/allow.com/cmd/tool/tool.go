@@ -0,0 +1,32 @@
+// Copyright 2021 Google LLC // want package:"used allow rules: 3"
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main // want "Allow rule 3 did not match any flow in this program: credentials were uploaded by the legacy client"
+
+import (
+	"levee_analysistest/allow.com/auth"
+	"levee_analysistest/allow.com/core"
+)
+
+func main() {
+	c := &core.Credentials{}
+	auth.Authenticate(c)
+	dump(c)
+	core.Send(c.Password)
+	core.Log(c.Password) // want "a source has reached a sink"
+}
+
+func dump(c *core.Credentials) {
+	core.Log(c)
+}
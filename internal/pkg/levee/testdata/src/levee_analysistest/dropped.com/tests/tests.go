@@ -0,0 +1,51 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"strings"
+
+	"levee_analysistest/summaries.com/core"
+	"levee_analysistest/summaries.com/thirdparty"
+)
+
+type Hasher interface {
+	Sum(v interface{}) int
+}
+
+func TestDroppedTaintIsReportedAtFirstCall(c core.Credentials) {
+	core.Sink(thirdparty.Hash(c)) // want `taint dropped at call to levee_analysistest/summaries.com/thirdparty.Hash: \{"Callee":"levee_analysistest/summaries.com/thirdparty.Hash","Package":"levee_analysistest/summaries.com/thirdparty","Receiver":"","Method":"Hash","Calls":2\}`
+}
+
+func TestDroppedTaintIsCountedOncePerCall(c core.Credentials) {
+	core.Sink(thirdparty.Hash(c.Password))
+}
+
+func TestDroppedTaintAtInterfaceMethod(c core.Credentials, h Hasher) {
+	core.Sink(h.Sum(c)) // want `taint dropped at call to \(levee_analysistest/dropped.com/tests.Hasher\).Sum: \{.*"Receiver":"Hasher","Method":"Sum","Calls":1\}`
+}
+
+func TestSummarizedCallsDoNotDropTaint(c core.Credentials, e *thirdparty.Encoder) {
+	core.Sink(e.Encode(c))                 // want "a source has reached a sink"
+	core.Sink(strings.ToUpper(c.Password)) // want "a source has reached a sink"
+}
+
+func TestCallsToFunctionValuesAreNotReported(c core.Credentials, f func(interface{}) int) {
+	core.Sink(f(c))
+}
+
+func TestUntaintedCallsAreNotReported(c core.Credentials) {
+	core.Sink(thirdparty.Hash("user"))
+}
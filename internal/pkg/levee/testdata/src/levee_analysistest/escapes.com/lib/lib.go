@@ -0,0 +1,99 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"levee_analysistest/escapes.com/core"
+)
+
+type Token struct {
+	Value string
+}
+
+func Password(c core.Credentials) string {
+	return c.Password // want "^a source escapes through an exported function: returned by Password\n source: levee_analysistest/escapes.com/core.Credentials at .*lib.go:25:15$"
+}
+
+func Login(c core.Credentials) (string, error) {
+	return c.Password, nil // want "^a source escapes through an exported function: returned by Login\n source: levee_analysistest/escapes.com/core.Credentials at .*lib.go:29:12$"
+}
+
+func Fill(c core.Credentials, dst *string) {
+	*dst = c.Password // want "^a source escapes through an exported function: stored into parameter dst of Fill\n source: levee_analysistest/escapes.com/core.Credentials at .*lib.go:33:11$"
+}
+
+func FillToken(c core.Credentials, t *Token) {
+	t.Value = c.Password // want "^a source escapes through an exported function: stored into parameter t of FillToken\n source: levee_analysistest/escapes.com/core.Credentials at .*lib.go:37:16$"
+}
+
+func FillAll(c core.Credentials, dst []string) {
+	dst[0] = c.Password // want "^a source escapes through an exported function: stored into parameter dst of FillAll\n source: levee_analysistest/escapes.com/core.Credentials at .*lib.go:41:14$"
+}
+
+func Remember(c core.Credentials) {
+	core.Log(c.Password) // want "^a source has reached a sink\n source: levee_analysistest/escapes.com/core.Credentials at .*lib.go:45:15$"
+}
+
+func Credentials(c core.Credentials) core.Credentials {
+	return c
+}
+
+func CredentialsPointer(c *core.Credentials) *core.Credentials {
+	return c
+}
+
+func Copy(c core.Credentials, dst *core.Credentials) {
+	dst.Password = c.Password
+}
+
+func User(c core.Credentials) string {
+	return c.User // want "^a source escapes through an exported function: returned by User\n source: levee_analysistest/escapes.com/core.Credentials at .*lib.go:61:11$"
+}
+
+func Suppressed(c core.Credentials) string {
+	return c.Password // levee.DoNotReport
+}
+
+func password(c core.Credentials) string {
+	return c.Password
+}
+
+func Closure(c core.Credentials) func() string {
+	return func() string {
+		return c.Password
+	}
+}
+
+func Length(c core.Credentials) int {
+	return len(c.Password)
+}
+
+type Vault struct {
+	password string
+}
+
+func (v *Vault) Unlock(c core.Credentials) string {
+	return c.Password // want "^a source escapes through an exported function: returned by \\(\\*Vault\\).Unlock\n source: levee_analysistest/escapes.com/core.Credentials at .*lib.go:87:24$"
+}
+
+func (v *Vault) Store(c core.Credentials) {
+	v.password = c.Password
+}
+
+type vault struct{}
+
+func (v *vault) Unlock(c core.Credentials) string {
+	return c.Password
+}
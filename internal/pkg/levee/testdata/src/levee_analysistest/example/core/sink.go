@@ -31,3 +31,8 @@ func Sinkf(format string, args ...interface{}) {}
 func FSinkf(writer io.Writer, args ...interface{}) {}
 
 func OneArgSink(interface{}) {}
+
+// A SinkWriter writes itself when its Sink method is called.
+type SinkWriter string
+
+func (w SinkWriter) Sink() {}
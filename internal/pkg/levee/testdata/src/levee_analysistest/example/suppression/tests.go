@@ -93,6 +93,36 @@ func TestSuppressPanic(s core.Source) {
 	)
 }
 
+func TestSuppressDeferredSink(s core.Source) {
+	// levee.DoNotReport
+	defer core.Sink(s)
+	defer core.Sink(s) // levee.DoNotReport
+
+	defer core.Sink( // levee.DoNotReport
+		s,
+	)
+
+	defer core.Sink(s) // want "a source has reached a sink"
+}
+
+func TestSuppressGoSink(s core.Source) {
+	// levee.DoNotReport
+	go core.Sink(s)
+	go core.Sink(s) // levee.DoNotReport
+
+	go core.Sink( // levee.DoNotReport
+		s,
+	)
+
+	go core.Sink(s) // want "a source has reached a sink"
+}
+
+func TestSuppressDeferredPanic(s core.Source) {
+	// levee.DoNotReport
+	defer panic(s)
+	defer panic(s) // want "a source has reached a sink"
+}
+
 func TestSuppressMultilineCall(s core.Source) {
 	// levee.DoNotReport
 	core.Sink(
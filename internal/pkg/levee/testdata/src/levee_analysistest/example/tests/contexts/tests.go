@@ -0,0 +1,105 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contexts
+
+import (
+	"context"
+	"levee_analysistest/example/core"
+)
+
+type ctxKey int
+
+const (
+	tokenKey ctxKey = iota
+	userKey
+)
+
+type sessionKey struct{}
+
+var requestKey = &struct{}{}
+
+func TestValueStoredUnderTaintedKeyIsTainted(ctx context.Context, s core.Source) {
+	ctx = context.WithValue(ctx, tokenKey, s.Data)
+	core.Sink(ctx.Value(tokenKey)) // want "a source has reached a sink"
+}
+
+func TestValuesStoredUnderOtherKeysAreNotTainted(ctx context.Context, s core.Source, user string) {
+	ctx = context.WithValue(ctx, tokenKey, s.Data)
+	ctx = context.WithValue(ctx, userKey, user)
+	core.Sink(ctx.Value(userKey))
+	core.Sink(ctx.Value("token"))
+	core.Sink(ctx.Err())
+}
+
+func TestChildContextInheritsTaintedKey(ctx context.Context, s core.Source) {
+	parent := context.WithValue(ctx, tokenKey, s.Data)
+	child := context.WithValue(parent, userKey, "user")
+	core.Sink(child.Value(tokenKey)) // want "a source has reached a sink"
+	core.Sink(child.Value(userKey))
+}
+
+func TestKeysAreRecordedPerContext(ctx context.Context, s core.Source) {
+	parent := context.WithValue(ctx, tokenKey, s.Data)
+	child := context.WithValue(parent, userKey, s.Data)
+	core.Sink(parent.Value(userKey))
+	core.Sink(child.Value(userKey)) // want "a source has reached a sink"
+}
+
+func TestKeysOfEitherContext(ctx context.Context, s core.Source, useString bool) {
+	if useString {
+		ctx = context.WithValue(ctx, "token", s.Data)
+	} else {
+		ctx = context.WithValue(ctx, tokenKey, s.Data)
+	}
+	core.Sink(ctx.Value("token"))  // want "a source has reached a sink"
+	core.Sink(ctx.Value(tokenKey)) // want "a source has reached a sink"
+	core.Sink(ctx.Value(userKey))
+}
+
+func TestStringKeys(ctx context.Context, s core.Source) {
+	ctx = context.WithValue(ctx, "token", s.Data)
+	core.Sink(ctx.Value("token")) // want "a source has reached a sink"
+	core.Sink(ctx.Value("user"))
+}
+
+func TestStructKeys(ctx context.Context, s core.Source) {
+	ctx = context.WithValue(ctx, sessionKey{}, s)
+	core.Sink(ctx.Value(sessionKey{})) // want "a source has reached a sink"
+	core.Sink(ctx.Value(tokenKey))
+}
+
+func TestGlobalKeys(ctx context.Context, s core.Source) {
+	ctx = context.WithValue(ctx, requestKey, s)
+	core.Sink(ctx.Value(requestKey)) // want "a source has reached a sink"
+	core.Sink(ctx.Value(sessionKey{}))
+}
+
+func TestContextPassedToSink(ctx context.Context, s core.Source) {
+	ctx = context.WithValue(ctx, tokenKey, s.Data)
+	core.Sink(ctx)                 // want "a context holding a source has reached a sink"
+	core.Sinkf("context: %v", ctx) // want "a context holding a source has reached a sink"
+}
+
+func TestContextAndSourcePassedToSink(ctx context.Context, s core.Source) {
+	ctx = context.WithValue(ctx, tokenKey, s.Data)
+	core.Sink(ctx, s) // want "^a source has reached a sink"
+}
+
+func TestUnknownKeyTaintsWholeContext(ctx context.Context, key interface{}, s core.Source) {
+	ctx = context.WithValue(ctx, key, s.Data)
+	core.Sink(ctx.Value("token")) // want "^a source has reached a sink"
+	core.Sink(ctx.Err())          // want "^a source has reached a sink"
+	core.Sink(ctx)                // want "^a source has reached a sink"
+}
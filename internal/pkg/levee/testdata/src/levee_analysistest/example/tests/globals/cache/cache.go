@@ -0,0 +1,33 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache holds package-level variables that are tainted in this package
+// and read in an importing package.
+package cache
+
+import (
+	"levee_analysistest/example/core"
+)
+
+var Token string
+
+var Innocuous string
+
+func Store(s core.Source) {
+	Token = s.Data
+}
+
+func StoreID(s core.Source) {
+	Innocuous = string(rune(s.ID))
+}
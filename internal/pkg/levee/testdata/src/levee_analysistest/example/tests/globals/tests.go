@@ -0,0 +1,123 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package globals contains tests for taint propagation through package-level variables.
+package globals
+
+import (
+	"fmt"
+
+	"levee_analysistest/example/core"
+	"levee_analysistest/example/tests/globals/cache"
+)
+
+var cachedToken string
+
+var cachedString string
+
+var cachedSanitized interface{}
+
+var cachedMap = map[string]string{}
+
+var secondHand string
+
+var tokenHolder struct {
+	Token string
+}
+
+var tokenArray [4]string
+
+var tokenSlice = make([]string, 4)
+
+var innocuous string
+
+func CacheToken(s core.Source) {
+	cachedToken = s.Data
+}
+
+func TestLoadFromTaintedGlobal() {
+	core.Sink(cachedToken) // want "a source has reached a sink"
+}
+
+func TestLoadFromTaintedGlobalInClosure() func() {
+	return func() {
+		core.Sink(cachedToken) // want "a source has reached a sink"
+	}
+}
+
+func CacheTaintedString(s core.Source) {
+	cachedString = fmt.Sprintf("%v", s)
+}
+
+func TestLoadFromGlobalHoldingTaintedValue() {
+	core.Sinkf("%s", cachedString) // want "a source has reached a sink"
+}
+
+func CacheSanitized(s core.Source) {
+	cachedSanitized = core.Sanitize(s)[0]
+}
+
+func TestLoadFromGlobalHoldingSanitizedValue() {
+	core.Sink(cachedSanitized)
+}
+
+func CacheInMap(s core.Source) {
+	cachedMap["token"] = s.Data
+}
+
+func TestLoadFromTaintedGlobalMap() {
+	core.Sink(cachedMap["token"]) // want "a source has reached a sink"
+}
+
+func CacheInField(s core.Source) {
+	tokenHolder.Token = s.Data
+}
+
+func TestLoadFromTaintedGlobalField() {
+	core.Sink(tokenHolder.Token) // want "a source has reached a sink"
+}
+
+func CacheAtIndex(s core.Source, i int) {
+	tokenArray[i] = s.Data
+	tokenSlice[i] = s.Data
+}
+
+func TestLoadFromTaintedGlobalElement(i int) {
+	core.Sink(tokenArray[i]) // want "a source has reached a sink"
+	core.Sink(tokenSlice[i]) // want "a source has reached a sink"
+}
+
+func CopyTaintedGlobal() {
+	secondHand = cachedToken
+}
+
+func TestLoadFromGlobalTaintedByOtherGlobal() {
+	core.Sink(secondHand) // want "a source has reached a sink"
+}
+
+func StoreInnocuous(i core.Innocuous) {
+	innocuous = i.Data
+}
+
+func TestLoadFromUntaintedGlobal() {
+	core.Sink(innocuous)
+}
+
+func TestLoadFromGlobalTaintedInImportedPackage() {
+	core.Sink(cache.Token) // want "a source has reached a sink"
+}
+
+func TestLoadFromUntaintedGlobalInImportedPackage() {
+	core.Sink(cache.Innocuous)
+}
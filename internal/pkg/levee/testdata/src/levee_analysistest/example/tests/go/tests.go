@@ -13,7 +13,8 @@
 // limitations under the License.
 
 // Package callcommon contains tests for Defer and Go instructions,
-// which should be treated similarly to Call instructions.
+// which should be treated similarly to Call instructions, both when
+// propagating taint and when calling sinks.
 package callcommon
 
 import (
@@ -33,3 +34,56 @@ func TestGoUnknownFunction(i *core.Innocuous, s core.Source) {
 }
 
 func baz(a, b interface{}) {}
+
+func TestGoSink(s core.Source) {
+	go core.Sink(s) // want "a source has reached a sink"
+}
+
+func TestGoSinkWithTaintedValue(s core.Source) {
+	str := fmt.Sprintf("%v", s)
+	go core.Sinkf("%s", str) // want "a source has reached a sink"
+}
+
+func TestGoSinkSanitized(s core.Source) {
+	sanitized := core.Sanitize(s)[0]
+	go core.Sink(sanitized)
+}
+
+func TestGoSinkWithNonSource(i core.Innocuous) {
+	go core.Sink(i)
+}
+
+func TestGoClosureCallingSink(s core.Source) {
+	go func() {
+		core.Sink(s) // want "a source has reached a sink"
+	}()
+}
+
+func TestDeferSink(s core.Source) {
+	defer core.Sink(s) // want "a source has reached a sink"
+}
+
+func TestDeferSinkWithTaintedValue(s core.Source) {
+	str := fmt.Sprintf("%v", s)
+	defer core.Sinkf("%s", str) // want "a source has reached a sink"
+}
+
+func TestDeferSinkSanitized(s core.Source) {
+	sanitized := core.Sanitize(s)[0]
+	defer core.Sink(sanitized)
+}
+
+func TestDeferSinkWithNonSource(i core.Innocuous) {
+	defer core.Sink(i)
+}
+
+func TestDeferSinkMethod(s core.Source) {
+	sinker := core.Sinker{}
+	defer sinker.Sink(s) // want "a source has reached a sink"
+}
+
+func TestDeferredClosureCallingSink(s core.Source) {
+	defer func() {
+		core.Sink(s) // want "a source has reached a sink"
+	}()
+}
@@ -28,11 +28,11 @@ func TestPanicIsASinkSanitized(source core.Source) {
 }
 
 func TestGoPanicIsASink(source core.Source) {
-	go panic(source) // TODO(#231): want "a source has reached a sink"
+	go panic(source) // want "a source has reached a sink"
 }
 
 func TestDeferPanicIsASink(source core.Source) {
-	defer panic(source) // TODO(#231): want "a source has reached a sink"
+	defer panic(source) // want "a source has reached a sink"
 }
 
 func TestPanicOnNonSourceDoesNotProduceReport(source core.Source) {
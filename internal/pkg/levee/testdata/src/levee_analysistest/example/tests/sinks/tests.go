@@ -69,3 +69,8 @@ func TestSinksSourceAndInnocuous(source core.Source, innoc core.Innocuous) {
 	core.Sink(innoc)
 	core.OneArgSink(innoc)
 }
+
+func TestSinkReachedThroughReceiver(source core.Source) {
+	w := core.SinkWriter(source.Data)
+	w.Sink() // want "a source has reached a sink"
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourcearguments
+
+import (
+	"flag"
+	"levee_analysistest/example/core"
+	"net/http"
+	"os"
+)
+
+func TestGetenvWithSensitiveName() {
+	core.Sink(os.Getenv("DB_PASSWORD")) // want "a source has reached a sink"
+}
+
+func TestGetenvWithInnocuousName() {
+	core.Sink(os.Getenv("HOME"))
+}
+
+func TestGetenvWithNonConstantName(name string) {
+	core.Sink(os.Getenv(name))
+}
+
+func TestLookupEnvWithSensitiveName() {
+	token, ok := os.LookupEnv("API_TOKEN")
+	if ok {
+		core.Sink(token) // want "a source has reached a sink"
+	}
+}
+
+func TestFlagWithSensitiveName() {
+	token := flag.String("api-token", "", "the API token")
+	user := flag.String("user", "", "the user")
+	flag.Parse()
+	core.Sink(*token) // want "a source has reached a sink"
+	core.Sink(*user)
+}
+
+func TestFlagWithSensitiveUsage() {
+	// Only the flag's name is considered.
+	name := flag.String("name", "", "not a password")
+	core.Sink(*name)
+}
+
+func TestHeaderGet(r *http.Request) {
+	core.Sink(r.Header.Get("Authorization")) // want "a source has reached a sink"
+	core.Sink(r.Header.Get("Accept"))
+}
+
+func TestHeaderLookup(r *http.Request) {
+	core.Sink(r.Header["Cookie"]) // want "a source has reached a sink"
+	core.Sink(r.Header["Accept"])
+}
+
+func TestHeaderLookupCommaOk(h http.Header) {
+	if cookie, ok := h["Cookie"]; ok {
+		core.Sink(cookie) // want "a source has reached a sink"
+	}
+}
+
+func TestHeaderLookupWithNonConstantKey(h http.Header, key string) {
+	core.Sink(h[key])
+}
+
+func TestLookupInOtherMapType(m map[string]string) {
+	core.Sink(m["Cookie"])
+}
+
+func TestArgumentPosition(s core.Settings) {
+	core.Sink(s.Get("db", "secret")) // want "a source has reached a sink"
+	core.Sink(s.Get("secret", "name"))
+	core.Sink(s["db.secret"]) // want "a source has reached a sink"
+	core.Sink(s["db.name"])
+}
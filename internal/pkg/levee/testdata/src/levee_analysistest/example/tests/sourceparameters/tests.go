@@ -0,0 +1,62 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourceparameters
+
+import (
+	"levee_analysistest/example/core"
+	"net/http"
+)
+
+func TestHandlerRequestIsSource(w http.ResponseWriter, r *http.Request) {
+	core.Sink(r) // want "a source has reached a sink"
+}
+
+func TestHandlerRequestFieldsAreTainted(w http.ResponseWriter, r *http.Request) {
+	core.Sink(r.Method)       // want "a source has reached a sink"
+	core.Sink(r.URL.RawQuery) // want "a source has reached a sink"
+	core.Sink(r.Form["id"])   // want "a source has reached a sink"
+}
+
+func TestHandlerResponseWriterIsNotSource(w http.ResponseWriter, r *http.Request) {
+	core.Sink(w)
+}
+
+func TestOtherSignatureIsNotSource(r *http.Request) {
+	core.Sink(r.Method)
+}
+
+type Server struct{}
+
+func (s *Server) TestMethodHandlerRequestIsSource(w http.ResponseWriter, r *http.Request) {
+	core.Sink(s)
+	core.Sink(r.URL) // want "a source has reached a sink"
+}
+
+func TestHandlerFuncLiteral() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		core.Sink(r.Host) // want "a source has reached a sink"
+	}
+}
+
+func Login(user, password string, remember bool) {
+	core.Sink(user)
+	core.Sink(password) // want "a source has reached a sink"
+	core.Sink(remember)
+}
+
+func Register(user, passphrase string) {
+	core.Sink(user)
+	core.Sink(passphrase)
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdlib
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/flate"
+	"compress/gzip"
+	"compress/lzw"
+	"compress/zlib"
+	"io"
+	"levee_analysistest/example/core"
+)
+
+func TestGzipNewReader(s core.Source) {
+	r, _ := gzip.NewReader(bytes.NewBufferString(s.Data))
+	core.Sink(r) // want "a source has reached a sink"
+}
+
+func TestGzipNewWriter(w *bytes.Buffer, s core.Source) {
+	w.WriteString(s.Data)
+	gz := gzip.NewWriter(w)
+	core.Sink(gz) // want "a source has reached a sink"
+}
+
+func TestGzipWriterWrite(gz *gzip.Writer, s core.Source) {
+	gz.Write([]byte(s.Data))
+	core.Sink(gz) // want "a source has reached a sink"
+}
+
+func TestFlateNewReader(s core.Source) {
+	r := flate.NewReader(bytes.NewBufferString(s.Data))
+	core.Sink(r) // want "a source has reached a sink"
+}
+
+func TestFlateNewWriterDict(w io.Writer, s core.Source) {
+	fw, _ := flate.NewWriterDict(w, flate.BestCompression, []byte(s.Data))
+	core.Sink(fw) // want "a source has reached a sink"
+}
+
+func TestZlibNewReader(s core.Source) {
+	r, _ := zlib.NewReader(bytes.NewBufferString(s.Data))
+	core.Sink(r) // want "a source has reached a sink"
+}
+
+func TestZlibNewWriter(w *bytes.Buffer, s core.Source) {
+	w.WriteString(s.Data)
+	zw := zlib.NewWriter(w)
+	core.Sink(zw) // want "a source has reached a sink"
+}
+
+func TestLZWNewReader(s core.Source) {
+	r := lzw.NewReader(bytes.NewBufferString(s.Data), lzw.LSB, 8)
+	core.Sink(r) // want "a source has reached a sink"
+}
+
+func TestBzip2NewReader(s core.Source) {
+	r := bzip2.NewReader(bytes.NewBufferString(s.Data))
+	core.Sink(r) // want "a source has reached a sink"
+}
@@ -0,0 +1,169 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdlib
+
+import (
+	"bufio"
+	"html"
+	htmltemplate "html/template"
+	"io"
+	"io/ioutil"
+	"levee_analysistest/example/core"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+func TestStrconvAtoi(s core.Source) {
+	_, err := strconv.Atoi(s.Data)
+	core.Sink(err) // want "a source has reached a sink"
+}
+
+func TestStrconvParseFloat(s core.Source) {
+	_, err := strconv.ParseFloat(s.Data, 64)
+	core.Sink(err) // want "a source has reached a sink"
+}
+
+func TestStrconvQuote(s core.Source) {
+	quoted := strconv.Quote(s.Data)
+	core.Sink(quoted) // want "a source has reached a sink"
+}
+
+func TestStrconvUnquote(s core.Source) {
+	unquoted, _ := strconv.Unquote(s.Data)
+	core.Sink(unquoted) // want "a source has reached a sink"
+}
+
+func TestStrconvUnquoteChar(s core.Source) {
+	_, _, tail, _ := strconv.UnquoteChar(s.Data, '"')
+	core.Sink(tail) // want "a source has reached a sink"
+}
+
+func TestURLParse(s core.Source) {
+	u, err := url.Parse(s.Data)
+	core.Sink(u)   // want "a source has reached a sink"
+	core.Sink(err) // want "a source has reached a sink"
+}
+
+func TestURLParseQuery(s core.Source) {
+	values, _ := url.ParseQuery(s.Data)
+	core.Sink(values) // want "a source has reached a sink"
+}
+
+func TestURLQueryEscape(s core.Source) {
+	escaped := url.QueryEscape(s.Data)
+	core.Sink(escaped) // want "a source has reached a sink"
+}
+
+func TestURLPathUnescape(s core.Source) {
+	unescaped, _ := url.PathUnescape(s.Data)
+	core.Sink(unescaped) // want "a source has reached a sink"
+}
+
+func TestURLValuesSet(values url.Values, s core.Source) {
+	values.Set("key", s.Data)
+	core.Sink(values.Encode()) // want "a source has reached a sink"
+}
+
+func TestURLUserPassword(s core.Source) {
+	user := url.UserPassword("user", s.Data)
+	password, _ := user.Password()
+	core.Sink(password) // want "a source has reached a sink"
+}
+
+func TestURLHostname(s core.Source) {
+	u, _ := url.Parse(s.Data)
+	host := u.Hostname()
+	core.Sink(host) // want "a source has reached a sink"
+}
+
+func TestPathDir(s core.Source) {
+	dir := path.Dir(s.Data)
+	core.Sink(dir) // want "a source has reached a sink"
+}
+
+func TestFilepathRel(s core.Source) {
+	rel, _ := filepath.Rel("/", s.Data)
+	core.Sink(rel) // want "a source has reached a sink"
+}
+
+func TestFilepathSplit(s core.Source) {
+	dir, file := filepath.Split(s.Data)
+	core.Sink(dir)  // want "a source has reached a sink"
+	core.Sink(file) // want "a source has reached a sink"
+}
+
+func TestBufioScanLines(s core.Source) {
+	_, token, _ := bufio.ScanLines([]byte(s.Data), true)
+	core.Sink(token) // want "a source has reached a sink"
+}
+
+func TestBufioScanRunes(s core.Source) {
+	_, token, _ := bufio.ScanRunes([]byte(s.Data), true)
+	core.Sink(token) // want "a source has reached a sink"
+}
+
+func TestBufioReaderReset(r *bufio.Reader, s core.Source) {
+	r.Reset(strings.NewReader(s.Data))
+	core.Sink(r) // want "a source has reached a sink"
+}
+
+func TestIOCopy(w io.Writer, s core.Source) {
+	io.Copy(w, strings.NewReader(s.Data))
+	core.Sink(w) // want "a source has reached a sink"
+}
+
+func TestIOReadAll(s core.Source) {
+	b, _ := ioutil.ReadAll(strings.NewReader(s.Data))
+	core.Sink(b) // want "a source has reached a sink"
+}
+
+func TestIONopCloser(s core.Source) {
+	rc := ioutil.NopCloser(strings.NewReader(s.Data))
+	core.Sink(rc) // want "a source has reached a sink"
+}
+
+func TestIOSectionReader(s core.Source) {
+	r := io.NewSectionReader(strings.NewReader(s.Data), 0, 8)
+	core.Sink(r) // want "a source has reached a sink"
+}
+
+func TestTextTemplateParse(t *template.Template, s core.Source) {
+	parsed, _ := t.Parse(s.Data)
+	core.Sink(parsed) // want "a source has reached a sink"
+}
+
+func TestTextTemplateExecute(t *template.Template, w io.Writer, s core.Source) {
+	t.Execute(w, s.Data)
+	core.Sink(w) // want "a source has reached a sink"
+}
+
+func TestHTMLTemplateParse(t *htmltemplate.Template, s core.Source) {
+	parsed := htmltemplate.Must(t.Parse(s.Data))
+	core.Sink(parsed) // want "a source has reached a sink"
+}
+
+func TestHTMLTemplateExecute(t *htmltemplate.Template, w io.Writer, s core.Source) {
+	t.Execute(w, s.Data)
+	core.Sink(w) // want "a source has reached a sink"
+}
+
+func TestHTMLEscapeString(s core.Source) {
+	escaped := html.EscapeString(s.Data)
+	core.Sink(escaped) // want "a source has reached a sink"
+}
@@ -0,0 +1,132 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdlib
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"io"
+	"levee_analysistest/example/core"
+)
+
+func TestSHA256Sum256(s core.Source) {
+	digest := sha256.Sum256([]byte(s.Data))
+	core.Sink(digest) // want "a source has reached a sink"
+}
+
+func TestSHA512Sum512(s core.Source) {
+	digest := sha512.Sum512([]byte(s.Data))
+	core.Sink(digest) // want "a source has reached a sink"
+}
+
+func TestSHA1Sum(s core.Source) {
+	digest := sha1.Sum([]byte(s.Data))
+	core.Sink(digest) // want "a source has reached a sink"
+}
+
+func TestMD5Sum(s core.Source) {
+	digest := md5.Sum([]byte(s.Data))
+	core.Sink(digest) // want "a source has reached a sink"
+}
+
+func TestHashWriteAndSum(h hash.Hash, s core.Source) {
+	h.Write([]byte(s.Data))
+	digest := h.Sum(nil)
+	core.Sink(digest) // want "a source has reached a sink"
+}
+
+func TestHMACKey(s core.Source, data []byte) {
+	mac := hmac.New(sha256.New, []byte(s.Data))
+	mac.Write(data)
+	core.Sink(mac.Sum(nil)) // want "a source has reached a sink"
+}
+
+func TestHashDoesNotTaintItsInputs(h hash.Hash, s core.Source, prefix []byte) {
+	h.Write([]byte(s.Data))
+	h.Sum(prefix)
+	core.Sink(prefix)
+}
+
+func TestAESKey(s core.Source, dst, src []byte) {
+	block, _ := aes.NewCipher([]byte(s.Data))
+	block.Encrypt(dst, src)
+	core.Sink(dst) // want "a source has reached a sink"
+}
+
+func TestBlockEncrypt(block cipher.Block, dst []byte, s core.Source) {
+	block.Encrypt(dst, []byte(s.Data))
+	core.Sink(dst) // want "a source has reached a sink"
+}
+
+func TestBlockDecrypt(block cipher.Block, dst []byte, s core.Source) {
+	block.Decrypt(dst, []byte(s.Data))
+	core.Sink(dst) // want "a source has reached a sink"
+}
+
+func TestAEADSeal(aead cipher.AEAD, nonce []byte, s core.Source) {
+	sealed := aead.Seal(nil, nonce, []byte(s.Data), nil)
+	core.Sink(sealed) // want "a source has reached a sink"
+}
+
+func TestAEADOpen(aead cipher.AEAD, nonce []byte, s core.Source) {
+	opened, _ := aead.Open(nil, nonce, []byte(s.Data), nil)
+	core.Sink(opened) // want "a source has reached a sink"
+}
+
+func TestAEADNonceDoesNotTaint(aead cipher.AEAD, plaintext []byte, s core.Source) {
+	sealed := aead.Seal(nil, []byte(s.Data), plaintext, nil)
+	core.Sink(sealed)
+}
+
+func TestGCMKey(s core.Source, nonce, plaintext []byte) {
+	block, _ := aes.NewCipher([]byte(s.Data))
+	aead, _ := cipher.NewGCM(block)
+	sealed := aead.Seal(nil, nonce, plaintext, nil)
+	core.Sink(sealed) // want "a source has reached a sink"
+}
+
+func TestStreamXORKeyStream(stream cipher.Stream, dst []byte, s core.Source) {
+	stream.XORKeyStream(dst, []byte(s.Data))
+	core.Sink(dst) // want "a source has reached a sink"
+}
+
+func TestCTRKey(s core.Source, iv, dst, src []byte) {
+	block, _ := aes.NewCipher([]byte(s.Data))
+	stream := cipher.NewCTR(block, iv)
+	stream.XORKeyStream(dst, src)
+	core.Sink(dst) // want "a source has reached a sink"
+}
+
+func TestBlockModeCryptBlocks(mode cipher.BlockMode, dst []byte, s core.Source) {
+	mode.CryptBlocks(dst, []byte(s.Data))
+	core.Sink(dst) // want "a source has reached a sink"
+}
+
+func TestRSAEncryptOAEP(random io.Reader, pub *rsa.PublicKey, s core.Source) {
+	ciphertext, _ := rsa.EncryptOAEP(sha256.New(), random, pub, []byte(s.Data), nil)
+	core.Sink(ciphertext) // want "a source has reached a sink"
+}
+
+func TestRSADecryptPKCS1v15(random io.Reader, priv *rsa.PrivateKey, s core.Source) {
+	plaintext, _ := rsa.DecryptPKCS1v15(random, priv, []byte(s.Data))
+	core.Sink(plaintext) // want "a source has reached a sink"
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdlib
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"levee_analysistest/example/core"
+	"strings"
+)
+
+func TestJSONMarshal(s core.Source) {
+	b, _ := json.Marshal(s.Data)
+	core.Sink(b) // want "a source has reached a sink"
+}
+
+func TestJSONMarshalIndent(s core.Source) {
+	b, _ := json.MarshalIndent(s.Data, "", " ")
+	core.Sink(b) // want "a source has reached a sink"
+}
+
+func TestJSONUnmarshal(s core.Source, v *map[string]string) {
+	json.Unmarshal([]byte(s.Data), v)
+	core.Sink(v) // want "a source has reached a sink"
+}
+
+func TestJSONEncoderEncode(enc *json.Encoder, s core.Source) {
+	enc.Encode(s.Data)
+	core.Sink(enc) // want "a source has reached a sink"
+}
+
+func TestJSONNewDecoder(s core.Source) {
+	dec := json.NewDecoder(strings.NewReader(s.Data))
+	core.Sink(dec) // want "a source has reached a sink"
+}
+
+func TestJSONCompact(dst *bytes.Buffer, s core.Source) {
+	json.Compact(dst, []byte(s.Data))
+	core.Sink(dst) // want "a source has reached a sink"
+}
+
+func TestBase64EncodeToString(s core.Source) {
+	encoded := base64.StdEncoding.EncodeToString([]byte(s.Data))
+	core.Sink(encoded) // want "a source has reached a sink"
+}
+
+func TestBase64Encode(dst []byte, s core.Source) {
+	base64.URLEncoding.Encode(dst, []byte(s.Data))
+	core.Sink(dst) // want "a source has reached a sink"
+}
+
+func TestBase64DecodeString(s core.Source) {
+	decoded, _ := base64.StdEncoding.DecodeString(s.Data)
+	core.Sink(decoded) // want "a source has reached a sink"
+}
+
+func TestBase64Decode(dst []byte, s core.Source) {
+	base64.StdEncoding.Decode(dst, []byte(s.Data))
+	core.Sink(dst) // want "a source has reached a sink"
+}
+
+func TestBase64NewDecoder(s core.Source) {
+	r := base64.NewDecoder(base64.StdEncoding, strings.NewReader(s.Data))
+	core.Sink(r) // want "a source has reached a sink"
+}
+
+func TestBase64NewEncoder(w *bytes.Buffer, s core.Source) {
+	w.WriteString(s.Data)
+	enc := base64.NewEncoder(base64.StdEncoding, w)
+	core.Sink(enc) // want "a source has reached a sink"
+}
+
+func TestBase32EncodeToString(s core.Source) {
+	encoded := base32.StdEncoding.EncodeToString([]byte(s.Data))
+	core.Sink(encoded) // want "a source has reached a sink"
+}
+
+func TestBase32DecodeString(s core.Source) {
+	decoded, _ := base32.HexEncoding.DecodeString(s.Data)
+	core.Sink(decoded) // want "a source has reached a sink"
+}
+
+func TestHexEncodeToString(s core.Source) {
+	encoded := hex.EncodeToString([]byte(s.Data))
+	core.Sink(encoded) // want "a source has reached a sink"
+}
+
+func TestHexEncode(dst []byte, s core.Source) {
+	hex.Encode(dst, []byte(s.Data))
+	core.Sink(dst) // want "a source has reached a sink"
+}
+
+func TestHexDecodeString(s core.Source) {
+	decoded, err := hex.DecodeString(s.Data)
+	core.Sink(decoded) // want "a source has reached a sink"
+	core.Sink(err)     // want "a source has reached a sink"
+}
+
+func TestHexDump(s core.Source) {
+	dump := hex.Dump([]byte(s.Data))
+	core.Sink(dump) // want "a source has reached a sink"
+}
+
+func TestPEMDecode(s core.Source) {
+	block, rest := pem.Decode([]byte(s.Data))
+	core.Sink(block) // want "a source has reached a sink"
+	core.Sink(rest)  // want "a source has reached a sink"
+}
+
+func TestPEMEncodeToMemory(s core.Source) {
+	block, _ := pem.Decode([]byte(s.Data))
+	encoded := pem.EncodeToMemory(block)
+	core.Sink(encoded) // want "a source has reached a sink"
+}
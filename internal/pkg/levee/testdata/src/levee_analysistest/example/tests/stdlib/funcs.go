@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"levee_analysistest/example/core"
+	"net/http"
 	"strings"
 	"sync"
 	"text/template"
@@ -74,6 +75,13 @@ func TestTaintFromReceiverToReturnValue(s core.Source) {
 	core.Sink(err)
 }
 
+func TestTaintFromArgumentToSecondReturnValue(s core.Source) {
+	advance, token, err := bufio.ScanLines([]byte(s.Data), true)
+	core.Sink(advance)
+	core.Sink(token) // want "a source has reached a sink"
+	core.Sink(err)
+}
+
 func TestTaintFromReceiverToArgument(str *string, src core.Source) {
 	dec := json.NewDecoder(strings.NewReader(src.Data))
 	dec.Decode(str)
@@ -97,3 +105,15 @@ func TestTaintVariadic(str *string, src core.Source) {
 	fmt.Sscan(src.Data, &str)
 	core.Sink(str) // TODO(#291) want "a source has reached a sink"
 }
+
+func TestTaintFromArgumentToVariadicElement(p *string, src core.Source) {
+	fmt.Sscan(src.Data, p)
+	core.Sink(p) // want "a source has reached a sink"
+}
+
+func TestTaintFromArgumentToReceiverField(req *http.Request, s core.Source) {
+	req.SetBasicAuth("user", s.Data)
+	core.Sink(req)        // want "a source has reached a sink"
+	core.Sink(req.Header) // want "a source has reached a sink"
+	core.Sink(req.URL)
+}
@@ -16,6 +16,8 @@ package stdlib
 
 import (
 	"context"
+	"encoding"
+	"encoding/json"
 	"io"
 	"levee_analysistest/example/core"
 	"strings"
@@ -41,6 +43,45 @@ func TestPropagationInvolvingFuncWithInterfaceParameter(rf io.ReaderFrom, s core
 
 func TestPropagateThroughContext(c context.Context, s core.Source) {
 	cc := context.WithValue(c, "data", s.Data)
-	core.Sink(cc.Err())         // want "a source has reached a sink"
+	core.Sink(cc.Err())
 	core.Sink(cc.Value("data")) // want "a source has reached a sink"
 }
+
+type marshaler string
+
+func (m marshaler) MarshalText() ([]byte, error) {
+	return []byte(m), nil
+}
+
+func TestPropagateThroughTextMarshaler(tm encoding.TextMarshaler, s core.Source) {
+	core.Sink(tm)
+	text, _ := tm.MarshalText()
+	core.Sink(text)
+
+	m := marshaler(s.Data)
+	text, _ = m.MarshalText()
+	core.Sink(text) // want "a source has reached a sink"
+}
+
+func TestPropagateThroughTextUnmarshaler(tu encoding.TextUnmarshaler, s core.Source) {
+	tu.UnmarshalText([]byte(s.Data))
+	core.Sink(tu) // want "a source has reached a sink"
+}
+
+func TestPropagateThroughJSONMarshaler(s core.Source) {
+	raw := json.RawMessage(s.Data)
+	var m json.Marshaler = raw
+	b, _ := m.MarshalJSON()
+	core.Sink(b) // want "a source has reached a sink"
+}
+
+func TestPropagateThroughJSONUnmarshaler(ju json.Unmarshaler, s core.Source) {
+	ju.UnmarshalJSON([]byte(s.Data))
+	core.Sink(ju) // want "a source has reached a sink"
+}
+
+func TestPropagateThroughWriterTo(w io.Writer, s core.Source) {
+	var wt io.WriterTo = strings.NewReader(s.Data)
+	wt.WriteTo(w)
+	core.Sink(w) // want "a source has reached a sink"
+}
@@ -0,0 +1,35 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"levee_analysistest/exclusion.com/core"
+)
+
+var secret interface{}
+
+func Handle(c *core.Credentials) {
+	core.Log(c) // want "a source has reached a sink"
+}
+
+func LogSecret() {
+	// secret is tainted in a generated file.
+	core.Log(secret) // want "a source has reached a sink"
+}
+
+func LogToken(t Token) {
+	// Token is declared in a generated file.
+	core.Log(t) // want "a source has reached a sink"
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"levee_analysistest/summaries.com/core"
+	"levee_analysistest/summaries.com/thirdparty"
+)
+
+func TestTaintFromVariadicValue(c core.Credentials) {
+	core.Sink(thirdparty.Values("user", c.Password)) // want "a source has reached a sink"
+}
+
+func TestTaintFromLaterVariadicValue(c core.Credentials) {
+	core.Sink(thirdparty.Values("user", "name", "password", c.Password)) // want "a source has reached a sink"
+}
+
+func TestNoTaintFromVariadicKey(c core.Credentials) {
+	core.Sink(thirdparty.Values(c.Password, "value"))
+}
+
+func TestTaintFromVariadicSlice(c core.Credentials) {
+	kv := []string{"password", c.Password}
+	core.Sink(thirdparty.Values(kv...)) // want "a source has reached a sink"
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+type User struct {
+	Name     string `levee:"safe"`
+	Nickname string `levee:"-"`
+	Password string
+}
+
+func (u *User) GetName() string {
+	return u.Name
+}
+
+func (u *User) GetPassword() string {
+	return u.Password
+}
+
+type Contact struct {
+	Email string `datapolicy:"pii.email"`
+	Phone string `datapolicy:"pii.phone,credential"`
+	City  string `datapolicy:"location"`
+}
+
+func Log(args ...interface{}) {}
+
+func TestSafeFieldsOfSourceType(u *User) {
+	Log(u.Name)
+	Log(u.Nickname)
+	Log(u.GetName())
+	Log(u.Password)      // want "a source has reached a sink"
+	Log(u.GetPassword()) // want "a source has reached a sink"
+	Log(u)               // want "a source has reached a sink"
+}
+
+func TestRegexpFieldTags(c Contact) {
+	Log(c.Email) // want "a source has reached a sink"
+	Log(c.Phone) // want "a source has reached a sink"
+	Log(c.City)
+}
@@ -0,0 +1,56 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commandinjection
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/exec"
+	"regexp"
+)
+
+var validName = regexp.MustCompile("^[a-z]+$")
+
+func TestCommandWithArgs() {
+	exec.Command(os.Args[1]) // want "untrusted input has reached an injection sink"
+}
+
+func TestCommandArgumentsWithArgs() {
+	exec.Command("ls", os.Args[1:]...) // want "untrusted input has reached an injection sink"
+}
+
+func TestCommandContextWithFlagArg(ctx context.Context) {
+	flag.Parse()
+	exec.CommandContext(ctx, "sh", "-c", flag.Arg(0)) // want "untrusted input has reached an injection sink"
+}
+
+func TestCommandWithConstant() {
+	exec.Command("ls", "-l")
+}
+
+func TestCommandWithValidatedArg() {
+	name := os.Args[1]
+	if !validName.MatchString(name) {
+		return
+	}
+	exec.Command("id", name)
+}
+
+func TestArgsLength() {
+	if len(os.Args) > 1 {
+		exec.Command("ls")
+	}
+}
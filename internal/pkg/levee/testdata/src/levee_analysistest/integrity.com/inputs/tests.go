@@ -0,0 +1,43 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inputs
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+)
+
+func TestRequestBody(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	exec.Command(string(body)) // want "untrusted input has reached an injection sink"
+}
+
+func TestRequestHeader(w http.ResponseWriter, r *http.Request) {
+	exec.Command(r.Header.Get("X-Command")) // want "untrusted input has reached an injection sink"
+}
+
+func TestRequestOutsideOfHandler(r *http.Request) {
+	exec.Command(r.Method)
+}
+
+func TestEnvironmentIsTrusted() {
+	exec.Command(os.Getenv("SHELL"))
+}
+
+func TestPanicIsNotASink() {
+	panic(os.Args[0])
+}
@@ -0,0 +1,49 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathinjection
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func TestJoin(w http.ResponseWriter, r *http.Request) {
+	filepath.Join("/var/www", r.URL.Query().Get("file")) // want "untrusted input has reached an injection sink"
+}
+
+func TestOpen(r *http.Request) {
+	os.Open(r.FormValue("file")) // want "untrusted input has reached an injection sink"
+}
+
+func TestWriteFileContentsAreNotSinks(r *http.Request) {
+	ioutil.WriteFile("/tmp/out", []byte(r.FormValue("contents")), 0644)
+	ioutil.WriteFile(r.FormValue("file"), nil, 0644) // want "untrusted input has reached an injection sink"
+}
+
+func TestBase(r *http.Request) {
+	name := r.FormValue("file")
+	name = filepath.Base(name)
+	os.Open(filepath.Join("/var/www", name))
+}
+
+func TestCookie(r *http.Request) {
+	c, err := r.Cookie("file")
+	if err != nil {
+		return
+	}
+	ioutil.ReadFile(c.Value) // want "untrusted input has reached an injection sink"
+}
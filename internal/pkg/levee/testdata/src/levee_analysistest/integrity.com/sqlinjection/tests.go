@@ -0,0 +1,61 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlinjection
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func TestQueryWithFormValue(db *sql.DB, r *http.Request) {
+	name := r.FormValue("name")
+	db.Query("SELECT * FROM users WHERE name = '" + name + "'") // want "untrusted input has reached an injection sink"
+}
+
+func TestQueryParametersAreNotSinks(db *sql.DB, r *http.Request) {
+	db.Query("SELECT * FROM users WHERE name = ?", r.FormValue("name"))
+	db.Exec("DELETE FROM users WHERE name = ?", r.FormValue("name"))
+}
+
+func TestContextMethods(ctx context.Context, db *sql.DB, r *http.Request) {
+	query := fmt.Sprintf("SELECT * FROM users WHERE id = %s", r.URL.Query().Get("id"))
+	db.QueryRowContext(ctx, query) // want "untrusted input has reached an injection sink"
+	db.QueryContext(ctx, "SELECT * FROM users WHERE id = ?", r.FormValue("id"))
+}
+
+func TestTransaction(tx *sql.Tx, r *http.Request) {
+	tx.Exec("UPDATE users SET name = '" + r.PostFormValue("name") + "'") // want "untrusted input has reached an injection sink"
+}
+
+func TestHandler(w http.ResponseWriter, r *http.Request) {
+	var db *sql.DB
+	db.Query("SELECT * FROM users WHERE name = '" + r.URL.Path + "'") // want "untrusted input has reached an injection sink"
+}
+
+func TestValidatedInput(db *sql.DB, r *http.Request) {
+	id := r.FormValue("id")
+	if _, err := strconv.Atoi(id); err != nil {
+		return
+	}
+	db.Query("SELECT * FROM users WHERE id = " + id)
+}
+
+func TestSuppressed(db *sql.DB, r *http.Request) {
+	// levee.DoNotReport
+	db.Query("SELECT * FROM users WHERE name = '" + r.FormValue("name") + "'")
+}
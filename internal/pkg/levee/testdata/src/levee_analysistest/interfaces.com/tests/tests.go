@@ -0,0 +1,76 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"levee_analysistest/interfaces.com/core"
+	"levee_analysistest/interfaces.com/datapolicy"
+	"levee_analysistest/interfaces.com/vendorlib"
+)
+
+type APIKey string
+
+func (APIKey) Secret() {}
+
+var _ datapolicy.Secret = APIKey("")
+
+type Credentials struct {
+	User     string `levee:"safe"`
+	Password string
+}
+
+func (*Credentials) Secret() {}
+
+func (c *Credentials) GetPassword() string {
+	return c.Password
+}
+
+type Session struct {
+	ID string
+}
+
+func (Session) Sensitive() {}
+
+type Public struct {
+	Name string
+}
+
+func TestNamedInterface(k APIKey, c *Credentials) {
+	core.Log(k)               // want "a source has reached a sink"
+	core.Log(c)               // want "a source has reached a sink"
+	core.Log(c.Password)      // want "a source has reached a sink"
+	core.Log(c.GetPassword()) // want "a source has reached a sink"
+	core.Log(c.User)
+}
+
+func TestInterfaceValue(s datapolicy.Secret) {
+	core.Log(s) // want "a source has reached a sink"
+}
+
+func TestMethods(s Session, k *vendorlib.Key) {
+	core.Log(s)    // want "a source has reached a sink"
+	core.Log(s.ID) // want "a source has reached a sink"
+	core.Log(k.ID) // want "a source has reached a sink"
+}
+
+func TestNamedInterfaceNotImportedByType(t vendorlib.Token) {
+	core.Log(t)       // want "a source has reached a sink"
+	core.Log(t.Value) // want "a source has reached a sink"
+}
+
+func TestNotASource(p Public) {
+	core.Log(p)
+	core.Log(p.Name)
+}
@@ -0,0 +1,31 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"levee_analysistest/multiplesources.com/core"
+)
+
+func TestAllSourcesAreListed(token core.Token, creds core.Credentials) {
+	core.Log(creds, token) // want "^a source has reached a sink\n source: levee_analysistest/multiplesources.com/core.Token at .*tests.go:21:30\n source: levee_analysistest/multiplesources.com/core.Credentials at .*tests.go:21:48$"
+}
+
+func TestSourcesAreListedOnce(creds core.Credentials) {
+	core.Log(creds, creds.Password, creds) // want "^a source has reached a sink\n source: levee_analysistest/multiplesources.com/core.Credentials at .*tests.go:25:31$"
+}
+
+func TestOnlySourcesReachingTheSinkAreListed(token core.Token, creds core.Credentials) {
+	core.Log(token.Value) // want "^a source has reached a sink\n source: levee_analysistest/multiplesources.com/core.Token at .*tests.go:29:46$"
+}
@@ -30,3 +30,8 @@ func TestPanickingOnTaintedValueIsAllowed() {
 	s := Source{Data: "password", ID: 1337}
 	panic(s)
 }
+
+func TestDeferredPanicOnTaintedValueIsAllowed() {
+	s := Source{Data: "password", ID: 1337}
+	defer panic(s)
+}
@@ -0,0 +1,33 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutil
+
+import (
+	"levee_analysistest/overrides.com/core"
+)
+
+func TestTokensAreNotSources(c core.Credentials) {
+	core.Log(c.Token)
+	core.Log(c.Password) // want "a source has reached a sink"
+}
+
+func TestPanicIsAllowed(c core.Credentials) {
+	panic(c.Password)
+}
+
+func TestRedactIsNotASanitizer(c core.Credentials) {
+	core.Redact(&c)
+	core.Log(c) // want "a source has reached a sink"
+}
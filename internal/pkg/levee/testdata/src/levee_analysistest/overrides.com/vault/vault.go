@@ -0,0 +1,34 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"levee_analysistest/overrides.com/core"
+)
+
+type Secret struct {
+	Name string
+	Key  string
+}
+
+func (s Secret) GetKey() string {
+	return s.Key
+}
+
+func TestAddedSourceType(s Secret) {
+	core.Log(s.Key)      // want "a source has reached a sink"
+	core.Log(s.GetKey()) // want "a source has reached a sink"
+	core.Log(s.Name)
+}
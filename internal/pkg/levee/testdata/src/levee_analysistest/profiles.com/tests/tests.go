@@ -0,0 +1,81 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+type Credentials struct {
+	User     string
+	Password string
+}
+
+func Log(args ...interface{}) {}
+
+func Query(query string, args ...interface{}) {}
+
+func Input() string {
+	return ""
+}
+
+func Validate(s string) {}
+
+func TestTopLevelConfiguration(c Credentials) {
+	Log(c.Password) // want "^a source has reached a sink\n source: [^\n]*$"
+	Log(c.User)
+	Log(Input())
+}
+
+func TestInjectionProfile() {
+	Query("SELECT " + Input()) // want "^a source has reached a sink\n source: .*\n profile: injection\n Use a parameterized query.$"
+	Query("SELECT ?", Input())
+}
+
+func TestInjectionProfileSanitizer() {
+	in := Input()
+	Validate(in)
+	Query("SELECT " + in)
+}
+
+func TestAuditProfile(c Credentials) {
+	Query("SELECT ?", c.User) // want "^a source has reached a sink\n source: .*\n profile: audit$"
+	Query("SELECT ?", c.Password)
+}
+
+func (c Credentials) GetUser() string {
+	return c.User
+}
+
+func (c Credentials) GetPassword() string {
+	return c.Password
+}
+
+var lastPassword, lastUser string
+
+func StoreCredentials(c Credentials) {
+	lastPassword = c.Password
+	lastUser = c.User
+}
+
+func TestTopLevelConfigurationTracksFieldPropagatorsAndGlobals(c Credentials) {
+	Log(c.GetPassword()) // want "^a source has reached a sink\n source: [^\n]*$"
+	Log(lastPassword)    // want "^a source has reached a sink\n source: [^\n]*$"
+	Log(c.GetUser())
+	Log(lastUser)
+}
+
+func TestProfilesTrackFieldPropagatorsAndGlobals(c Credentials) {
+	Query("SELECT ?", c.GetUser()) // want "^a source has reached a sink\n source: .*\n profile: audit$"
+	Query("SELECT ?", lastUser)    // want "^a source has reached a sink\n source: .*\n profile: audit$"
+	Query("SELECT ?", c.GetPassword())
+	Query("SELECT ?", lastPassword)
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"levee_analysistest/proto.com/core"
+	"levee_analysistest/proto.com/legacypb"
+	"levee_analysistest/proto.com/userpb"
+)
+
+func TestAnnotatedFields(u *userpb.User) {
+	core.Log(u.Password) // want "a source has reached a sink"
+	core.Log(u.Name)
+}
+
+func TestGetters(u *userpb.User) {
+	core.Log(u.GetPassword())           // want "a source has reached a sink"
+	core.Log(u.GetApiKey())             // want "a source has reached a sink"
+	core.Log(u.GetSession().GetToken()) // want "a source has reached a sink"
+	core.Log(u.GetName())
+	core.Log(u.GetHint())
+	core.Log(u.GetSession().Id)
+}
+
+func TestMessageWithAnnotatedFields(u *userpb.User) {
+	core.Log(u) // want "a source has reached a sink"
+}
+
+func TestOptionConfiguredByNumber(a *legacypb.Account) {
+	core.Log(a.Secret) // want "a source has reached a sink"
+	core.Log(a.Login)
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Redacted has a String method that does not return its source field.
+type Redacted struct {
+	Data string
+	ID   int
+}
+
+func (r Redacted) String() string {
+	return "Redacted{ID: " + strconv.Itoa(r.ID) + "}"
+}
+
+// RedactedGo has String and GoString methods that do not return its source field.
+type RedactedGo struct {
+	Data string
+	ID   int
+}
+
+func (r RedactedGo) String() string {
+	return "RedactedGo{<redacted>}"
+}
+
+func (r RedactedGo) GoString() string {
+	return fmt.Sprintf("core.RedactedGo{ID: %d}", r.ID)
+}
+
+// RedactedPtr has a String method that does not return its source field,
+// but the method can only be called on a pointer.
+type RedactedPtr struct {
+	Data string
+	ID   int
+}
+
+func (r *RedactedPtr) String() string {
+	return "RedactedPtr{<redacted>}"
+}
+
+// RedactedError is an error whose Error method does not return its source field.
+type RedactedError struct {
+	Data string
+	ID   int
+}
+
+func (r RedactedError) Error() string {
+	return "error for " + strconv.Itoa(r.ID)
+}
+
+// Leaky has a String method that returns its source field.
+type Leaky struct {
+	Data string
+	ID   int
+}
+
+func (l Leaky) String() string {
+	return "Leaky{" + l.Data + "}"
+}
+
+// Formatted has a Format method that does not write its source field.
+type Formatted struct {
+	Data string
+	ID   int
+}
+
+func (f Formatted) Format(s fmt.State, verb rune) {
+	fmt.Fprintf(s, "Formatted{ID: %d}", f.ID)
+}
+
+// LeakyFormatted has a Format method that writes its source field.
+type LeakyFormatted struct {
+	Data string
+	ID   int
+}
+
+func (f LeakyFormatted) Format(s fmt.State, verb rune) {
+	s.Write([]byte(f.Data))
+}
+
+// TaggedRedacted is a source because of its tagged field,
+// and has a String method that does not return that field.
+type TaggedRedacted struct {
+	Data string `levee:"source"`
+	ID   int
+}
+
+func (t TaggedRedacted) String() string {
+	return "TaggedRedacted{<redacted>}"
+}
+
+func Sink(args ...interface{}) {}
+
+func Sinkf(format string, args ...interface{}) {}
+
+func OneArgSink(arg interface{}) {}
@@ -0,0 +1,106 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"bytes"
+	"fmt"
+
+	"levee_analysistest/redactedformatting.com/core"
+)
+
+func TestRedactedStringIsUsedByPrint(r core.Redacted) {
+	core.Sink(r)
+	core.Sink(&r)
+}
+
+func TestRedactedStringIsUsedByPrintfVerbs(r core.Redacted) {
+	core.Sinkf("%v %+v %s %q %x %X", r, r, r, r, r, r)
+}
+
+func TestRedactedStringIsNotUsedBySharpV(r core.Redacted) {
+	core.Sinkf("%#v", r) // want "a source has reached a sink"
+}
+
+func TestRedactedStringIsNotUsedByNonStringVerbs(r core.Redacted) {
+	core.Sinkf("%d", r) // want "a source has reached a sink"
+}
+
+func TestTypeVerbIsSafe(l core.Leaky) {
+	core.Sinkf("%T", l)
+}
+
+func TestExtraArgumentsArePrintedWithV(r core.Redacted, l core.Leaky) {
+	core.Sinkf("no verbs", r)
+	core.Sinkf("no verbs", l) // want "a source has reached a sink"
+}
+
+func TestNonConstantFormat(r core.Redacted, format string) {
+	core.Sinkf(format, r) // want "a source has reached a sink"
+}
+
+func TestRedactedGoStringIsUsedBySharpV(r core.RedactedGo) {
+	core.Sinkf("%#v %v", r, r)
+}
+
+func TestRedactedStringOnPointerIsNotUsedForValues(r core.RedactedPtr) {
+	core.Sink(r) // want "a source has reached a sink"
+}
+
+func TestRedactedStringOnPointerIsUsedForPointers(r *core.RedactedPtr) {
+	core.Sink(r)
+}
+
+func TestRedactedError(e core.RedactedError) {
+	core.Sinkf("%v", e)
+}
+
+func TestLeakyString(l core.Leaky) {
+	core.Sink(l) // want "a source has reached a sink"
+}
+
+func TestRedactedFormatIsUsedForAllVerbs(f core.Formatted) {
+	core.Sinkf("%#v %d %v", f, f, f)
+}
+
+func TestLeakyFormat(f core.LeakyFormatted) {
+	core.Sink(f) // want "a source has reached a sink"
+}
+
+func TestTaggedRedacted(t core.TaggedRedacted) {
+	core.Sink(t)
+}
+
+func TestRedactedWithFmt(r core.Redacted) {
+	s := fmt.Sprintf("%v", r)
+	core.Sink(s)
+
+	var b bytes.Buffer
+	fmt.Fprintln(&b, r)
+	core.Sink(b.String())
+}
+
+func TestRedactedWithFmtSharpV(r core.Redacted) {
+	s := fmt.Sprintf("%#v", r)
+	core.Sink(s) // want "a source has reached a sink"
+}
+
+func TestRedactedPassedToNonFormattingSink(r core.Redacted) {
+	core.OneArgSink(r) // want "a source has reached a sink"
+}
+
+func TestRedactedSourceField(r core.Redacted) {
+	core.Sink(r.Data) // want "a source has reached a sink"
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"io"
+	"levee_analysistest/example/core"
+	"log"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+)
+
+func TestZapLoggerWithSourceField(logger *zap.Logger, s core.Source) {
+	logger.With(zap.String("data", s.Data)).Info("done") // want "a source has reached a sink"
+}
+
+func TestZapLoggerWithSourceFieldIsReusable(logger *zap.Logger, s core.Source) {
+	l := logger.With(zap.Any("source", s)).Named("saver")
+	l.Info("saving")    // want "a source has reached a sink"
+	l.Error("failed")   // want "a source has reached a sink"
+	logger.Info("done") // the original logger does not carry the source field
+}
+
+func TestZapLoggerWithInnocuousField(logger *zap.Logger, s core.Source) {
+	logger.With(zap.Int("id", s.ID)).Info("done")
+}
+
+func TestZapLoggerWithSensitiveKey(logger *zap.Logger, password string) {
+	logger.With(zap.String("password", password)).Info("login") // want "a source has reached a sink"
+}
+
+func TestSugaredLoggerWithSource(logger *zap.Logger, s core.Source) {
+	logger.Sugar().With("data", s.Data).Infow("done") // want "a source has reached a sink"
+}
+
+func TestLogrusEntryWithSourceField(entry *logrus.Entry, s core.Source) {
+	entry.WithField("data", s.Data).Warn("done") // want "a source has reached a sink"
+}
+
+func TestLogrusEntryChainWithSourceFields(entry *logrus.Entry, s core.Source) {
+	entry.WithFields(logrus.Fields{"data": s.Data}).WithField("id", s.ID).Info("done") // want "a source has reached a sink"
+}
+
+func TestLogrusLoggerWithSensitiveKey(token string) {
+	logrus.New().WithField("token", token).Info("login") // want "a source has reached a sink"
+}
+
+func TestLogrusEntryFromLoggerWithSourceField(s core.Source) {
+	logrus.NewEntry(logrus.New()).WithField("data", s.Data).Info("done") // want "a source has reached a sink"
+}
+
+func TestLogrusReceiverIsNotSensitiveForPrint(entry *logrus.Entry, s core.Source) {
+	entry.WithField("data", s.Data).Print("done")
+}
+
+func TestLogrusReceiverFromFunctionIsSensitiveForPrint(s core.Source) {
+	logrus.WithField("data", s.Data).Print("done") // want "a source has reached a sink"
+}
+
+func TestLoggerFromConstructorWithSourcePrefix(w io.Writer, s core.Source) {
+	log.New(w, s.Data, 0).Print("done") // want "a source has reached a sink"
+}
+
+func TestLoggerFromConstructorWithInnocuousPrefix(w io.Writer, s core.Source) {
+	log.New(w, "saver: ", 0).Print("done")
+}
+
+func TestLogrusArgumentIsSensitiveForPrint(entry *logrus.Entry, s core.Source) {
+	entry.Print(s) // want "a source has reached a sink"
+}
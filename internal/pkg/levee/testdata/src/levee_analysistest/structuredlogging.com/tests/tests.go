@@ -0,0 +1,76 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"levee_analysistest/example/core"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+)
+
+func TestZapAttributeWithSourceData(logger *zap.Logger, s core.Source) {
+	logger.Info("saving", zap.String("data", s.Data)) // want "a source has reached a sink"
+}
+
+func TestZapAnyAttributeWithSource(logger *zap.Logger, s core.Source) {
+	logger.Info("saving", zap.Any("source", s)) // want "a source has reached a sink"
+}
+
+func TestZapAttributeWithInnocuousData(logger *zap.Logger, s core.Source) {
+	logger.Info("saving", zap.Int("id", s.ID))
+}
+
+func TestZapAttributeBuiltBeforeSink(logger *zap.Logger, s core.Source) {
+	field := zap.String("data", s.Data)
+	fields := []zap.Field{zap.Int("id", s.ID), field}
+	logger.Error("failed", fields...) // want "a source has reached a sink"
+}
+
+func TestZapAttributeWithSensitiveKey(logger *zap.Logger, password string) {
+	logger.Info("login", zap.String("password", password)) // want "a source has reached a sink"
+}
+
+func TestZapAttributeWithSensitiveKeyIgnoresCase(logger *zap.Logger, token int) {
+	logger.Info("login", zap.Int("SessionToken", token)) // want "a source has reached a sink"
+}
+
+func TestZapAttributeWithInnocuousKey(logger *zap.Logger, user string) {
+	logger.Info("login", zap.String("user", user))
+}
+
+func TestLogrusFieldsWithSourceData(s core.Source) {
+	logrus.WithFields(logrus.Fields{"data": s.Data}).Info("saving") // want "a source has reached a sink"
+}
+
+func TestLogrusFieldWithSourceData(s core.Source) {
+	logrus.WithField("data", s.Data).Warn("saving") // want "a source has reached a sink"
+}
+
+func TestLogrusFieldsWithSensitiveKey(user, password string) {
+	logrus.WithFields(logrus.Fields{"user": user, "password": password}).Info("login") // want "a source has reached a sink"
+}
+
+func TestLogrusFieldWithSensitiveKey(token string) {
+	logrus.WithField("token", token).Info("login") // want "a source has reached a sink"
+}
+
+func TestLogrusFieldsWithInnocuousKeys(user string, s core.Source) {
+	logrus.WithFields(logrus.Fields{"user": user, "id": s.ID}).Info("login")
+}
+
+func TestLogrusFieldWithNonConstantKey(key, value string) {
+	logrus.WithField(key, value).Info("login")
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"levee_analysistest/summaries.com/core"
+	"levee_analysistest/summaries.com/thirdparty"
+)
+
+func TestConfiguredSummaryTaintsResult(c core.Credentials, e *thirdparty.Encoder) {
+	core.Sink(e.Encode(c)) // want "a source has reached a sink"
+}
+
+func TestConfiguredSummaryTaintsArgument(c core.Credentials, dst *string) {
+	thirdparty.Copy(dst, c.Password)
+	core.Sink(dst) // want "a source has reached a sink"
+}
+
+func TestConfiguredSummaryOnlyAppliesToIfTaintedArguments(c core.Credentials, e *thirdparty.Encoder) {
+	core.Sink(e.Encode("user"))
+}
+
+func TestUnsummarizedCallDropsTaint(c core.Credentials) {
+	core.Sink(thirdparty.Hash(c))
+}
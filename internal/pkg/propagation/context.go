@@ -0,0 +1,240 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation
+
+import (
+	"go/token"
+	"go/types"
+
+	"github.com/google/go-flow-levee/internal/pkg/utils"
+	"golang.org/x/tools/go/ssa"
+)
+
+// Taint that is stored in a context via context.WithValue is recorded under
+// the value's key, so that only the values retrieved from the context using
+// that key are tainted, e.g.:
+//   ctx = context.WithValue(ctx, tokenKey, token)
+//   ctx.Value(tokenKey) // tainted
+//   ctx.Value(userKey)  // not tainted
+//   ctx.Err()           // not tainted
+// The keys are recorded for each call to context.WithValue. A context holds the keys
+// recorded for the call that returned it and for its parents. Other contexts that are
+// reached by the Propagation, e.g. one returned by context.WithCancel, carry taint as a whole.
+
+// taintContextCall propagates taint through calls that store values into
+// a context or retrieve values from a context carrying keyed taint.
+// It returns false if the call should be handled like any other call.
+func (prop *Propagation) taintContextCall(call *ssa.Call, maxInstrReached map[*ssa.BasicBlock]int, lastBlockVisited *ssa.BasicBlock) bool {
+	cc := call.Common()
+
+	if isWithValue(call) {
+		key, val := cc.Args[1], cc.Args[2]
+		switch {
+		// A tainted key taints the whole context.
+		case prop.tainted[key.(ssa.Node)]:
+			return false
+		case prop.tainted[val.(ssa.Node)]:
+			k, ok := contextKey(key)
+			if !ok {
+				return false
+			}
+			prop.storedKeys[call] = k
+		// The parent carries keyed taint, which is inherited by the new context.
+		case !prop.isKeyedContext(call):
+			return false
+		}
+		prop.taintReferrers(call, maxInstrReached, lastBlockVisited)
+		return true
+	}
+
+	if !cc.IsInvoke() || !prop.tainted[cc.Value.(ssa.Node)] || !prop.isKeyedContext(cc.Value) {
+		return false
+	}
+	// Only values stored under a tainted key are tainted.
+	// Other context methods, e.g. Err and Done, do not propagate keyed taint.
+	if cc.Method.Name() == "Value" && len(cc.Args) == 1 {
+		if k, ok := contextKey(cc.Args[0]); !ok || prop.keysOf(cc.Value, map[ssa.Value]bool{})[k] {
+			prop.taintReferrers(call, maxInstrReached, lastBlockVisited)
+		} else {
+			prop.untaintedLookups = append(prop.untaintedLookups, call)
+		}
+	}
+	return true
+}
+
+// isKeyedContext determines whether a value is a context that carries keyed taint.
+func (prop *Propagation) isKeyedContext(v ssa.Value) bool {
+	return isContext(v.Type()) && len(prop.keysOf(v, map[ssa.Value]bool{})) > 0
+}
+
+// keysOf returns the keys under which a context holds tainted values.
+// A context that may be one of several contexts, i.e. a Phi, holds the keys of each of them.
+func (prop *Propagation) keysOf(v ssa.Value, visited map[ssa.Value]bool) map[string]bool {
+	if visited[v] {
+		return nil
+	}
+	visited[v] = true
+	keys := map[string]bool{}
+	switch t := v.(type) {
+	case *ssa.Call:
+		if !isWithValue(t) {
+			return nil
+		}
+		for k := range prop.keysOf(t.Call.Args[0], visited) {
+			keys[k] = true
+		}
+		if k, ok := prop.storedKeys[t]; ok {
+			keys[k] = true
+		}
+	case *ssa.Phi:
+		for _, e := range t.Edges {
+			for k := range prop.keysOf(e, visited) {
+				keys[k] = true
+			}
+		}
+	}
+	return keys
+}
+
+// missedStoredKeys records the keys of tainted values that were stored into a context
+// after the context was visited, e.g. because the context was reached through
+// its parent first. It returns true if the search needs to be repeated,
+// i.e. if a new key was recorded or if a lookup that was not tainted
+// uses a key that is now known to be tainted.
+func (prop *Propagation) missedStoredKeys() bool {
+	missed := false
+	for n := range prop.tainted {
+		call, ok := n.(*ssa.Call)
+		if !ok || !isWithValue(call) {
+			continue
+		}
+		if _, ok := prop.storedKeys[call]; ok || !prop.tainted[call.Call.Args[2].(ssa.Node)] {
+			continue
+		}
+		if k, ok := contextKey(call.Call.Args[1]); ok {
+			prop.storedKeys[call] = k
+			missed = true
+		}
+	}
+	for _, call := range prop.untaintedLookups {
+		if k, _ := contextKey(call.Call.Args[0]); prop.keysOf(call.Call.Value, map[ssa.Value]bool{})[k] {
+			missed = true
+		}
+	}
+	return missed
+}
+
+func isWithValue(call *ssa.Call) bool {
+	callee := call.Call.StaticCallee()
+	if callee == nil || len(call.Call.Args) != 3 {
+		return false
+	}
+	path, recv, name := utils.DecomposeFunction(callee)
+	return path == "context" && recv == "" && name == "WithValue"
+}
+
+// IsTaintedByContextOnly determines whether a call is only tainted because
+// it receives a context that carries keyed taint, e.g. core.Sink(ctx).
+// The receiver of a method call is only considered if includeReceiver is true.
+func (prop Propagation) IsTaintedByContextOnly(call ssa.CallInstruction, includeReceiver bool) bool {
+	if len(prop.storedKeys) == 0 {
+		return false
+	}
+	args := prop.taintedArgs(call, includeReceiver)
+	for _, a := range args {
+		if !prop.carriesContextOnly(a) {
+			return false
+		}
+	}
+	return len(args) > 0
+}
+
+// carriesContextOnly determines whether a tainted value is a context,
+// possibly converted to an interface or passed in variadic arguments.
+func (prop Propagation) carriesContextOnly(v ssa.Value) bool {
+	switch t := v.(type) {
+	case *ssa.MakeInterface:
+		return prop.carriesContextOnly(t.X)
+	case *ssa.ChangeInterface:
+		return prop.carriesContextOnly(t.X)
+	case *ssa.Slice:
+		varargs, ok := t.X.(*ssa.Alloc)
+		if !ok || varargs.Referrers() == nil {
+			return false
+		}
+		found := false
+		for _, r := range *varargs.Referrers() {
+			ia, ok := r.(*ssa.IndexAddr)
+			if !ok || ia.Referrers() == nil {
+				continue
+			}
+			for _, iar := range *ia.Referrers() {
+				store, ok := iar.(*ssa.Store)
+				if !ok || !prop.tainted[store.Val.(ssa.Node)] {
+					continue
+				}
+				if !prop.carriesContextOnly(store.Val) {
+					return false
+				}
+				found = true
+			}
+		}
+		return found
+	}
+	return isContext(v.Type())
+}
+
+// contextKey returns a string identifying a context key, if the key is
+// a constant, a value loaded from a package-level variable,
+// or the zero value of a struct type, e.g. sessionKey{}.
+func contextKey(key ssa.Value) (string, bool) {
+	if mi, ok := key.(*ssa.MakeInterface); ok {
+		key = mi.X
+	}
+	switch t := key.(type) {
+	case *ssa.Const:
+		return t.String(), true
+	case *ssa.UnOp:
+		if t.Op != token.MUL {
+			break
+		}
+		switch x := t.X.(type) {
+		case *ssa.Global:
+			return x.String(), true
+		// A composite literal that is not lifted to a register is loaded from an Alloc.
+		case *ssa.Alloc:
+			if isZeroValue(x) {
+				return types.TypeString(utils.Dereference(x.Type()), nil) + "{}", true
+			}
+		}
+	}
+	return "", false
+}
+
+// isZeroValue determines whether an Alloc is only loaded from,
+// i.e. whether it holds the zero value of its type.
+func isZeroValue(alloc *ssa.Alloc) bool {
+	for _, r := range *alloc.Referrers() {
+		if load, ok := r.(*ssa.UnOp); !ok || load.Op != token.MUL {
+			return false
+		}
+	}
+	return true
+}
+
+func isContext(t types.Type) bool {
+	path, name := utils.DecomposeType(t)
+	return path == "context" && name == "Context"
+}
@@ -0,0 +1,283 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation
+
+import (
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"github.com/google/go-flow-levee/internal/pkg/utils"
+	"golang.org/x/tools/go/ssa"
+)
+
+// RedactingFormatters is a set of formatting methods (see IsFormattingMethod)
+// that are known not to propagate source data from their receiver.
+type RedactingFormatters map[types.Object]bool
+
+// IsRedacting determines whether a formatting method is known to redact source data.
+func (rf RedactingFormatters) IsRedacting(meth *types.Func) bool {
+	return rf[meth]
+}
+
+// IsFormattingMethod determines whether a method is one that the fmt package
+// uses to format a value, i.e. whether it implements one of
+// fmt.Formatter, fmt.GoStringer, fmt.Stringer or error.
+func IsFormattingMethod(meth *types.Func) bool {
+	sig, ok := meth.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return false
+	}
+	switch meth.Name() {
+	case "Error", "GoString", "String":
+		return sig.Params().Len() == 0 && sig.Results().Len() == 1 && isString(sig.Results().At(0).Type())
+	case "Format":
+		if sig.Params().Len() != 2 || sig.Results().Len() != 0 {
+			return false
+		}
+		path, name := utils.DecomposeType(sig.Params().At(0).Type())
+		return path == "fmt" && name == "State" && types.Identical(sig.Params().At(1).Type(), types.Typ[types.Rune])
+	}
+	return false
+}
+
+func isString(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Kind() == types.String
+}
+
+// A formatVerb is a printing verb, e.g. %v or %#v.
+type formatVerb struct {
+	verb  rune
+	sharp bool
+}
+
+// printVerb is the verb used by the Print family of functions.
+var printVerb = formatVerb{verb: 'v'}
+
+// isRedactedWhenFormatted determines whether a value converted to an interface
+// only flows into formatting functions, and whether all of the formatting methods
+// that would be used to format it are known to redact source data.
+func (prop *Propagation) isRedactedWhenFormatted(mi *ssa.MakeInterface) bool {
+	if mi.Referrers() == nil || len(*mi.Referrers()) == 0 {
+		return false
+	}
+	for _, r := range *mi.Referrers() {
+		verbs, ok := prop.formattingVerbs(r)
+		if !ok {
+			return false
+		}
+		for _, v := range verbs {
+			if !prop.isRedactedWith(mi.X.Type(), v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isRedactedWith determines whether formatting a value of type t with the given verb
+// is known to redact source data. This follows the rules used by the fmt package
+// to select a formatting method.
+func (prop *Propagation) isRedactedWith(t types.Type, v formatVerb) bool {
+	// %T only prints the type of the value.
+	if v.verb == 'T' {
+		return true
+	}
+	if format := formattingMethod(t, "Format"); format != nil {
+		return prop.formatters.IsRedacting(format)
+	}
+	if v.sharp && v.verb == 'v' {
+		goString := formattingMethod(t, "GoString")
+		return goString != nil && prop.formatters.IsRedacting(goString)
+	}
+	switch v.verb {
+	case 'v', 's', 'x', 'X', 'q':
+		if errorMeth := formattingMethod(t, "Error"); errorMeth != nil {
+			return prop.formatters.IsRedacting(errorMeth)
+		}
+		str := formattingMethod(t, "String")
+		return str != nil && prop.formatters.IsRedacting(str)
+	}
+	return false
+}
+
+// formattingMethod returns the formatting method with the given name
+// in the method set of t, or nil if there is no such method.
+func formattingMethod(t types.Type, name string) *types.Func {
+	sel := types.NewMethodSet(t).Lookup(nil, name)
+	if sel == nil {
+		return nil
+	}
+	meth, ok := sel.Obj().(*types.Func)
+	if !ok || !IsFormattingMethod(meth) {
+		return nil
+	}
+	return meth
+}
+
+// formattingVerbs determines the verbs that will be used to format a value stored
+// into the variadic arguments of one or more formatting calls, e.g.:
+//   t0 = new [1]interface{} (varargs)
+//   t1 = &t0[0:int]
+//   *t1 = t2            <-- the instruction being examined
+//   t3 = slice t0[:]
+//   t4 = fmt.Sprintf("%v":string, t3...)
+// ok is false if the instruction is not such a store, or if the verbs cannot be determined.
+func (prop *Propagation) formattingVerbs(instr ssa.Instruction) (verbs []formatVerb, ok bool) {
+	store, ok := instr.(*ssa.Store)
+	if !ok {
+		return nil, false
+	}
+	ia, ok := store.Addr.(*ssa.IndexAddr)
+	if !ok {
+		return nil, false
+	}
+	varargs, ok := ia.X.(*ssa.Alloc)
+	if !ok || varargs.Referrers() == nil {
+		return nil, false
+	}
+	idx, ok := ia.Index.(*ssa.Const)
+	if !ok {
+		return nil, false
+	}
+	argIndex := int(idx.Int64())
+
+	for _, r := range *varargs.Referrers() {
+		switch t := r.(type) {
+		case *ssa.IndexAddr:
+			continue
+		case *ssa.Slice:
+			if t.Referrers() == nil {
+				continue
+			}
+			for _, sr := range *t.Referrers() {
+				call, ok := sr.(ssa.CallInstruction)
+				if !ok {
+					return nil, false
+				}
+				v, ok := prop.verbForVariadicArg(call.Common(), t, argIndex)
+				if !ok {
+					return nil, false
+				}
+				verbs = append(verbs, v)
+			}
+		default:
+			return nil, false
+		}
+	}
+	return verbs, true
+}
+
+// verbForVariadicArg determines the verb that a formatting call will use to format
+// the argIndex-th element of its variadic arguments.
+func (prop *Propagation) verbForVariadicArg(call *ssa.CallCommon, varargs ssa.Value, argIndex int) (formatVerb, bool) {
+	sig := call.Signature()
+	if !sig.Variadic() || len(call.Args) == 0 || call.Args[len(call.Args)-1] != varargs {
+		return formatVerb{}, false
+	}
+	callee := call.StaticCallee()
+	if callee == nil {
+		return formatVerb{}, false
+	}
+	printf, ok := prop.formattingStyle(callee)
+	if !ok {
+		return formatVerb{}, false
+	}
+	if !printf {
+		return printVerb, true
+	}
+
+	format, ok := call.Args[len(call.Args)-2].(*ssa.Const)
+	if !ok || format.Value == nil || format.Value.Kind() != constant.String {
+		return formatVerb{}, false
+	}
+	verbs, ok := parseFormat(constant.StringVal(format.Value))
+	if !ok {
+		return formatVerb{}, false
+	}
+	// Extra arguments are printed using %v, e.g. "%!(EXTRA string=value)".
+	if argIndex >= len(verbs) {
+		return printVerb, true
+	}
+	return verbs[argIndex], true
+}
+
+// formattingStyle determines whether a function formats its variadic arguments
+// like fmt.Print (printf is false) or like fmt.Printf (printf is true).
+// ok is false if the function is not known to format its arguments.
+// Functions in the fmt package are formatting functions, as are sinks whose
+// signature matches that of a fmt function.
+func (prop *Propagation) formattingStyle(fn *ssa.Function) (printf bool, ok bool) {
+	path, recv, name := utils.DecomposeFunction(fn)
+	if path == "fmt" && recv == "" {
+		switch name {
+		case "Print", "Println", "Sprint", "Sprintln", "Fprint", "Fprintln":
+			return false, true
+		case "Printf", "Sprintf", "Fprintf", "Errorf":
+			return true, true
+		}
+		return false, false
+	}
+	if !prop.config.IsSink(path, recv, name) {
+		return false, false
+	}
+	params := fn.Signature.Params()
+	last, ok := params.At(params.Len() - 1).Type().(*types.Slice)
+	if !ok || !types.Identical(last.Elem(), types.NewInterfaceType(nil, nil)) {
+		return false, false
+	}
+	printf = strings.HasSuffix(name, "f") && params.Len() >= 2 && isString(params.At(params.Len()-2).Type())
+	return printf, true
+}
+
+// parseFormat returns the verbs in a printf-style format string, in the order
+// in which they consume arguments. A '*' width or precision consumes an argument,
+// and is represented by a '*' verb.
+// ok is false if the format string uses explicit argument indexes.
+func parseFormat(format string) (verbs []formatVerb, ok bool) {
+	runes := []rune(format)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			continue
+		}
+		i++
+		v := formatVerb{}
+		// flags
+		for ; i < len(runes) && strings.ContainsRune("#+- 0", runes[i]); i++ {
+			if runes[i] == '#' {
+				v.sharp = true
+			}
+		}
+		// width and precision
+		for ; i < len(runes) && strings.ContainsRune("0123456789.*[", runes[i]); i++ {
+			switch runes[i] {
+			case '*':
+				verbs = append(verbs, formatVerb{verb: '*'})
+			case '[':
+				return nil, false
+			}
+		}
+		if i >= len(runes) {
+			break
+		}
+		if runes[i] == '%' {
+			continue
+		}
+		v.verb = runes[i]
+		verbs = append(verbs, v)
+	}
+	return verbs, true
+}
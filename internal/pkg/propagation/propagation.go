@@ -24,6 +24,7 @@ import (
 	"github.com/google/go-flow-levee/internal/pkg/config"
 	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
 	"github.com/google/go-flow-levee/internal/pkg/sanitizer"
+	"github.com/google/go-flow-levee/internal/pkg/sourcetype"
 	"github.com/google/go-flow-levee/internal/pkg/utils"
 	"golang.org/x/tools/go/pointer"
 	"golang.org/x/tools/go/ssa"
@@ -38,16 +39,65 @@ type Propagation struct {
 	sanitizers   []*sanitizer.Sanitizer
 	config       *config.Config
 	taggedFields fieldtags.ResultType
+	formatters   RedactingFormatters
+	// storedKeys contains, for each call to context.WithValue that stores
+	// a tainted value, the key under which the value is stored.
+	storedKeys map[*ssa.Call]string
+	// untaintedLookups contains the calls to Value on a context carrying keyed taint
+	// that were not tainted, because their key was not known to be tainted.
+	untaintedLookups []*ssa.Call
+	// opaqueTypes contains the types whose fields are all considered tainted,
+	// e.g. the type of a parameter that is configured as a source.
+	opaqueTypes map[types.Type]bool
+	// taintedFields contains the struct fields that are tainted
+	// because a summary propagates taint into them.
+	taintedFields map[*types.Var]bool
+	// droppedCalls contains the calls that taint reached,
+	// but did not propagate through for lack of a summary.
+	droppedCalls []ssa.CallInstruction
 }
 
 // Taint performs a depth-first search of the graph formed by SSA Referrers and
 // Operands relationships, beginning at the given root node.
-func Taint(n ssa.Node, conf *config.Config, taggedFields fieldtags.ResultType) Propagation {
+// The formatters are used to determine whether values that are only
+// formatted by fmt-style functions propagate taint.
+// Since a tainted value may be stored into a context after the context has been used,
+// the search is repeated when it finds keys that were not known while it was visiting.
+func Taint(n ssa.Node, conf *config.Config, taggedFields fieldtags.ResultType, formatters RedactingFormatters) Propagation {
+	storedKeys := map[*ssa.Call]string{}
+	for {
+		prop := taintFrom(n, conf, taggedFields, formatters, storedKeys)
+		if !prop.missedStoredKeys() {
+			return prop
+		}
+		storedKeys = prop.storedKeys
+	}
+}
+
+// taintFrom performs a single search, given the keys that are known to be tainted.
+func taintFrom(n ssa.Node, conf *config.Config, taggedFields fieldtags.ResultType, formatters RedactingFormatters, storedKeys map[*ssa.Call]string) Propagation {
 	prop := Propagation{
-		root:         n,
-		tainted:      make(map[ssa.Node]bool),
-		config:       conf,
-		taggedFields: taggedFields,
+		root:          n,
+		tainted:       make(map[ssa.Node]bool),
+		config:        conf,
+		taggedFields:  taggedFields,
+		formatters:    formatters,
+		storedKeys:    make(map[*ssa.Call]string),
+		opaqueTypes:   make(map[types.Type]bool),
+		taintedFields: make(map[*types.Var]bool),
+	}
+	for call, k := range storedKeys {
+		prop.storedKeys[call] = k
+	}
+	// A source that does not have a source type, e.g. an HTTP request parameter
+	// or a cookie returned by a source function, does not have designated
+	// source fields. Instead, all of its fields are tainted.
+	if v, ok := n.(ssa.Value); ok && isOpaqueRoot(v) {
+		for _, t := range resultTypes(v.Type()) {
+			if !sourcetype.IsSourceType(conf, taggedFields, t) {
+				prop.opaqueTypes[utils.Dereference(t)] = true
+			}
+		}
 	}
 	maxInstrReached := map[*ssa.BasicBlock]int{}
 
@@ -118,7 +168,9 @@ func (prop *Propagation) shouldNotTaint(n ssa.Node, maxInstrReached map[*ssa.Bas
 		// then we would be propagating taint backwards in time, so stop traversing.
 		// (If the call is an operand, then it is being used as a value, so it does
 		// not matter when the call occurred.)
-		if _, ok := instr.(*ssa.Call); ok && instrIndex < maxInstrReached[instr.Block()] && isReferrer {
+		// This also applies to Defer and Go instructions, whose arguments are
+		// evaluated when the instruction is executed.
+		if _, ok := instr.(ssa.CallInstruction); ok && instrIndex < maxInstrReached[instr.Block()] && isReferrer {
 			return true
 		}
 	}
@@ -164,8 +216,14 @@ func (prop *Propagation) taintNeighbors(n ssa.Node, maxInstrReached map[*ssa.Bas
 		prop.taint(t.X.(ssa.Node), maxInstrReached, lastBlockVisited, false)
 
 	// Only the Addr (the Value that is being written to) should be visited.
+	// A variable that is not lifted to a register, e.g. a local struct, is an Alloc.
+	// As described above, an Alloc that is not a Source value is not visited,
+	// but storing a tainted value into it taints its loads.
 	case *ssa.Store:
 		prop.taint(t.Addr.(ssa.Node), maxInstrReached, lastBlockVisited, false)
+		if alloc, ok := t.Addr.(*ssa.Alloc); ok && !sourcetype.IsSourceType(prop.config, prop.taggedFields, alloc.Type()) {
+			prop.taintReferrers(alloc, maxInstrReached, lastBlockVisited)
+		}
 
 	// Only the Map itself can be tainted by an Update.
 	// The Key can't be tainted.
@@ -198,8 +256,17 @@ func (prop *Propagation) taintNeighbors(n ssa.Node, maxInstrReached map[*ssa.Bas
 	case *ssa.Const, *ssa.FreeVar, *ssa.Global, *ssa.Lookup, *ssa.Parameter:
 		prop.taintReferrers(n, maxInstrReached, lastBlockVisited)
 
+	// If the configuration allows it, a value whose formatting methods redact source data
+	// does not propagate taint when it is only used as an argument to formatting functions.
+	case *ssa.MakeInterface:
+		if prop.config.AllowRedactedFormatting && prop.isRedactedWhenFormatted(t) {
+			return
+		}
+		prop.taintReferrers(n, maxInstrReached, lastBlockVisited)
+		prop.taintOperands(n, maxInstrReached, lastBlockVisited)
+
 	// These nodes are both Instructions and Values, and currently have no special restrictions.
-	case *ssa.MakeInterface, *ssa.TypeAssert, *ssa.UnOp:
+	case *ssa.TypeAssert, *ssa.UnOp:
 		prop.taintReferrers(n, maxInstrReached, lastBlockVisited)
 		prop.taintOperands(n, maxInstrReached, lastBlockVisited)
 
@@ -211,8 +278,39 @@ func (prop *Propagation) taintNeighbors(n ssa.Node, maxInstrReached map[*ssa.Bas
 	}
 }
 
+// isOpaqueRoot determines whether a source value may be opaque, i.e.
+// whether its fields are tainted even if they are not source fields.
+// Accessing a source field is excluded, since the field itself is the source.
+func isOpaqueRoot(v ssa.Value) bool {
+	switch v.(type) {
+	case *ssa.Field, *ssa.FieldAddr:
+		return false
+	}
+	return true
+}
+
+// resultTypes returns the types of the elements of a tuple,
+// e.g. the results of a call, or the type itself if it is not a tuple.
+func resultTypes(t types.Type) []types.Type {
+	tuple, ok := t.(*types.Tuple)
+	if !ok {
+		return []types.Type{t}
+	}
+	var ts []types.Type
+	for i := 0; i < tuple.Len(); i++ {
+		ts = append(ts, tuple.At(i).Type())
+	}
+	return ts
+}
+
 func (prop *Propagation) taintField(n ssa.Node, maxInstrReached map[*ssa.BasicBlock]int, lastBlockVisited *ssa.BasicBlock, t types.Type, field int) {
-	if !prop.config.IsSourceField(utils.DecomposeField(t, field)) && !prop.taggedFields.IsSourceField(t, field) {
+	if prop.taggedFields.IsSafeField(t, field) {
+		return
+	}
+	if prop.opaqueTypes[utils.Dereference(t)] {
+		// the fields of the field's type are also tainted, e.g. req.URL.RawQuery
+		prop.opaqueTypes[utils.Dereference(n.(ssa.Value).Type())] = true
+	} else if !sourcetype.IsSourceField(prop.config, prop.taggedFields, t, field) && !prop.taintedFields[structField(t, field)] {
 		return
 	}
 	prop.taintReferrers(n, maxInstrReached, lastBlockVisited)
@@ -252,6 +350,10 @@ func (prop *Propagation) taintCall(call *ssa.Call, maxInstrReached map[*ssa.Basi
 		return
 	}
 
+	if prop.taintContextCall(call, maxInstrReached, lastBlockVisited) {
+		return
+	}
+
 	prop.taintStdlibCall(call, maxInstrReached, lastBlockVisited)
 }
 
@@ -343,6 +445,109 @@ func (prop Propagation) IsTainted(instr ssa.Instruction) bool {
 	return prop.tainted[instr.(ssa.Node)] && !prop.isSanitizedAt(instr)
 }
 
+// IsTaintedOperand determines whether an instruction is tainted by the Propagation
+// through the given operand, e.g. one of the values returned by a Return instruction.
+func (prop Propagation) IsTaintedOperand(instr ssa.Instruction, v ssa.Value) bool {
+	n, ok := v.(ssa.Node)
+	return ok && prop.IsTainted(instr) && prop.tainted[n]
+}
+
+// IsTaintedValue determines whether a value, e.g. a parameter of the function
+// holding the Propagation's root, is tainted by the Propagation.
+func (prop Propagation) IsTaintedValue(v ssa.Value) bool {
+	n, ok := v.(ssa.Node)
+	return ok && prop.tainted[n]
+}
+
+// DroppedCalls returns the calls to which a tainted value is passed, but through which
+// taint is not propagated, because their function has no summary and is neither
+// a sink nor a sanitizer. The calls are returned in the order they were reached.
+func (prop Propagation) DroppedCalls() []ssa.CallInstruction {
+	var dropped []ssa.CallInstruction
+	seen := map[ssa.CallInstruction]bool{}
+	for _, call := range prop.droppedCalls {
+		if !seen[call] && prop.IsTaintedCall(call, true) {
+			seen[call] = true
+			dropped = append(dropped, call)
+		}
+	}
+	return dropped
+}
+
+// IsTaintedCall determines whether a call is tainted by the Propagation through
+// one of its arguments. The receiver of a method call is only considered
+// if includeReceiver is true.
+func (prop Propagation) IsTaintedCall(call ssa.CallInstruction, includeReceiver bool) bool {
+	return prop.IsTainted(call) && len(prop.taintedArgs(call, includeReceiver)) > 0
+}
+
+// IsTaintedArg determines whether a call is tainted by the Propagation through
+// the argument at the given position, not counting the receiver.
+func (prop Propagation) IsTaintedArg(call ssa.CallInstruction, i int) bool {
+	cc := call.Common()
+	args := cc.Args
+	// For static method calls, the receiver is the first argument.
+	if !cc.IsInvoke() && cc.Signature().Recv() != nil && len(args) > 0 {
+		args = args[1:]
+	}
+	return prop.IsTainted(call) && i < len(args) && prop.tainted[args[i].(ssa.Node)]
+}
+
+// SourceFieldAccesses returns the accesses to source fields that were visited
+// by the Propagation, other than its root, in visitation order.
+func (prop Propagation) SourceFieldAccesses() []ssa.Node {
+	var accesses []ssa.Node
+	for _, n := range prop.preOrder {
+		if n == prop.root {
+			continue
+		}
+		var t types.Type
+		var field int
+		switch f := n.(type) {
+		case *ssa.Field:
+			t, field = f.X.Type(), f.Field
+		case *ssa.FieldAddr:
+			t, field = f.X.Type(), f.Field
+		default:
+			continue
+		}
+		if sourcetype.IsSourceField(prop.config, prop.taggedFields, t, field) {
+			accesses = append(accesses, n)
+		}
+	}
+	return accesses
+}
+
+// From performs a taint propagation beginning at the given node,
+// using the same configuration as the Propagation.
+func (prop Propagation) From(n ssa.Node) Propagation {
+	return Taint(n, prop.config, prop.taggedFields, prop.formatters)
+}
+
+// taintedArgs returns the tainted arguments of a call. The receiver of
+// a method call is only included if includeReceiver is true.
+func (prop Propagation) taintedArgs(call ssa.CallInstruction, includeReceiver bool) []ssa.Value {
+	var tainted []ssa.Value
+	cc := call.Common()
+	args := cc.Args
+	switch {
+	// For "invoke" calls, Value is the receiver.
+	case cc.IsInvoke():
+		if includeReceiver && prop.tainted[cc.Value.(ssa.Node)] {
+			tainted = append(tainted, cc.Value)
+		}
+	// For static method calls, the receiver is the first argument.
+	case cc.Signature().Recv() != nil && len(args) > 0 && !includeReceiver:
+		args = args[1:]
+	}
+	for _, a := range args {
+		if prop.tainted[a.(ssa.Node)] {
+			tainted = append(tainted, a)
+		}
+	}
+	return tainted
+}
+
 // isSanitizedAt determines whether the taint propagated from the Propagation's root
 // is sanitized when it reaches the target instruction.
 func (prop Propagation) isSanitizedAt(instr ssa.Instruction) bool {
@@ -15,17 +15,25 @@
 package propagation
 
 import (
+	"go/types"
+
 	"github.com/google/go-flow-levee/internal/pkg/propagation/summary"
+	"github.com/google/go-flow-levee/internal/pkg/utils"
 	"golang.org/x/tools/go/ssa"
 )
 
 // taintStdlibCall propagates taint through a static call to a standard
 // library function, or through an implementation of a standard library
 // interface function, provided that the function's taint propagation behavior
-// is known (i.e. the function has a summary).
+// is known (i.e. the function has a summary). Summaries provided by the
+// configuration are used for functions that have no built-in summary.
 func (prop *Propagation) taintStdlibCall(callInstr ssa.CallInstruction, maxInstrReached map[*ssa.BasicBlock]int, lastBlockVisited *ssa.BasicBlock) {
-	summ := summary.For(callInstr)
+	summ := summary.ExtendedFor(callInstr)
+	if summ == nil {
+		summ = prop.configuredSummary(callInstr)
+	}
 	if summ == nil {
+		prop.recordDroppedCall(callInstr)
 		return
 	}
 
@@ -37,19 +45,20 @@ func (prop *Propagation) taintStdlibCall(callInstr ssa.CallInstruction, maxInstr
 	args = append(args, callInstr.Common().Args...)
 
 	// Determine whether we need to propagate taint.
-	tainted := int64(0)
-	for i, a := range args {
-		if prop.tainted[a.(ssa.Node)] {
-			tainted |= 1 << i
+	tainted := false
+	for _, p := range summ.IfTainted {
+		if p.Index < len(args) && prop.isTaintedPath(args[p.Index], p.Accesses) {
+			tainted = true
+			break
 		}
 	}
-	if (tainted & summ.IfTainted) == 0 {
+	if !tainted {
 		return
 	}
 
 	// Taint call arguments.
-	for _, i := range summ.TaintedArgs {
-		prop.taint(args[i].(ssa.Node), maxInstrReached, lastBlockVisited, false)
+	for _, p := range summ.TaintedArgs {
+		prop.taintPath(args[p.Index], p.Accesses, maxInstrReached, lastBlockVisited, false)
 	}
 
 	// Only actual Call instructions can have Referrers.
@@ -68,6 +77,9 @@ func (prop *Propagation) taintStdlibCall(callInstr ssa.CallInstruction, maxInstr
 	// the Referrers.
 	if call.Common().Signature().Results().Len() == 1 {
 		if len(summ.TaintedRets) > 0 {
+			for _, p := range summ.TaintedRets {
+				prop.taintFields(call.Type(), p.Accesses)
+			}
 			prop.taintReferrers(call, maxInstrReached, lastBlockVisited)
 		}
 		return
@@ -83,7 +95,202 @@ func (prop *Propagation) taintStdlibCall(callInstr ssa.CallInstruction, maxInstr
 		e := r.(*ssa.Extract)
 		indexToExtract[e.Index] = e
 	}
-	for i := range summ.TaintedRets {
-		prop.taint(indexToExtract[i], maxInstrReached, lastBlockVisited, true)
+	// A returned value that is not used has no Extract.
+	for _, p := range summ.TaintedRets {
+		if e, ok := indexToExtract[p.Index]; ok {
+			prop.taintPath(e, p.Accesses, maxInstrReached, lastBlockVisited, true)
+		}
+	}
+}
+
+// configuredSummary returns the summary provided by the configuration
+// for a static call, or nil if there is none.
+func (prop *Propagation) configuredSummary(call ssa.CallInstruction) *summary.Extended {
+	callee := call.Common().StaticCallee()
+	if callee == nil {
+		return nil
+	}
+	fs, ok := prop.config.Summary(utils.DecomposeFunction(callee))
+	if !ok {
+		return nil
+	}
+	// A summary that does not fit the function's signature is ignored.
+	for _, i := range fs.TaintedArgs {
+		if i >= len(call.Common().Args) {
+			return nil
+		}
+	}
+	return &summary.Extended{
+		IfTainted:   summary.Positions(fs.IfTainted),
+		TaintedArgs: summary.Positions(fs.TaintedArgs),
+		TaintedRets: summary.Positions(fs.TaintedRets),
+	}
+}
+
+// IsBuilt determines whether a value is the result of a method call that attaches
+// its arguments to the receiver it returns, e.g. logger.With("key", value),
+// i.e. a method whose summary propagates taint from an argument other than
+// its receiver to its results.
+// The result of a function that is not a method, e.g. bytes.NewBuffer(data),
+// is not built: it holds the data that it is constructed from.
+func (prop Propagation) IsBuilt(v ssa.Value) bool {
+	call, ok := v.(*ssa.Call)
+	if !ok || !call.Call.IsInvoke() && call.Call.Signature().Recv() == nil {
+		return false
+	}
+	summ := summary.ExtendedFor(call)
+	if summ == nil {
+		summ = prop.configuredSummary(call)
+	}
+	if summ == nil || len(summ.TaintedRets) == 0 {
+		return false
+	}
+	for _, p := range summ.IfTainted {
+		if p.Index > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// recordDroppedCall records a call through which taint is not propagated.
+// Calls to sinks are not recorded, since the taint reaching them is reported.
+func (prop *Propagation) recordDroppedCall(call ssa.CallInstruction) {
+	if callee := call.Common().StaticCallee(); callee != nil && prop.config.IsSink(utils.DecomposeFunction(callee)) {
+		return
+	}
+	prop.droppedCalls = append(prop.droppedCalls, call)
+}
+
+// isTaintedPath determines whether the part of v designated by accesses is tainted.
+// An element of a variadic argument is tainted if the value stored into it is tainted.
+// Otherwise, the part is tainted if v is tainted, or if the value
+// of a field access selecting it is tainted.
+func (prop *Propagation) isTaintedPath(v ssa.Value, accesses []summary.Access) bool {
+	if len(accesses) > 0 && accesses[0].Field == "" {
+		if elems, ok := storedElements(v, accesses[0].Element); ok {
+			for _, e := range elems {
+				if prop.IsTaintedValue(e) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	if prop.IsTaintedValue(v) {
+		return true
+	}
+	if len(accesses) == 0 || accesses[0].Field == "" || v.Referrers() == nil {
+		return false
+	}
+	field := summary.SelectField(v.Type(), accesses[0].Field)
+	if field == nil {
+		return false
+	}
+	for _, r := range *v.Referrers() {
+		if sel, ok := r.(ssa.Value); ok && selectedField(sel) == field && prop.isTaintedPath(sel, accesses[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// taintPath taints v, as well as the part of it designated by accesses:
+// the struct fields along the path are tainted, and if the path selects
+// elements of a variadic argument, the values stored into them are tainted.
+func (prop *Propagation) taintPath(v ssa.Value, accesses []summary.Access, maxInstrReached map[*ssa.BasicBlock]int, lastBlockVisited *ssa.BasicBlock, isReferrer bool) {
+	// The fields must be tainted before v is visited, since visiting v visits the field accesses.
+	prop.taintFields(v.Type(), accesses)
+	prop.taint(v.(ssa.Node), maxInstrReached, lastBlockVisited, isReferrer)
+	if len(accesses) == 0 || accesses[0].Field != "" {
+		return
+	}
+	elems, _ := storedElements(v, accesses[0].Element)
+	for _, e := range elems {
+		prop.taint(e.(ssa.Node), maxInstrReached, lastBlockVisited, false)
+	}
+}
+
+// taintFields records the struct fields selected by accesses on a value of type t as tainted.
+func (prop *Propagation) taintFields(t types.Type, accesses []summary.Access) {
+	for _, a := range accesses {
+		if a.Field == "" {
+			t = elementType(t)
+			if t == nil {
+				return
+			}
+			continue
+		}
+		f := summary.SelectField(t, a.Field)
+		if f == nil {
+			return
+		}
+		prop.taintedFields[f] = true
+		t = f.Type()
+	}
+}
+
+// storedElements returns the values stored into the elements of v that are
+// selected by an element access, provided that v slices an array whose elements
+// are stored in place, as is the case for the variadic arguments of a call.
+func storedElements(v ssa.Value, element int) ([]ssa.Value, bool) {
+	slice, ok := v.(*ssa.Slice)
+	if !ok {
+		return nil, false
+	}
+	array, ok := slice.X.(*ssa.Alloc)
+	if !ok || array.Referrers() == nil {
+		return nil, false
+	}
+	var elems []ssa.Value
+	for _, r := range *array.Referrers() {
+		addr, ok := r.(*ssa.IndexAddr)
+		if !ok || addr.Referrers() == nil {
+			continue
+		}
+		if element != summary.AnyElement {
+			if c, ok := addr.Index.(*ssa.Const); !ok || c.Int64() != int64(element) {
+				continue
+			}
+		}
+		for _, rr := range *addr.Referrers() {
+			if store, ok := rr.(*ssa.Store); ok && store.Addr == addr {
+				elems = append(elems, store.Val)
+			}
+		}
+	}
+	return elems, true
+}
+
+// elementType returns the type of the elements of a slice or array,
+// or of an array pointed to by t, or nil if t has no elements.
+func elementType(t types.Type) types.Type {
+	switch tt := utils.Dereference(t).Underlying().(type) {
+	case *types.Slice:
+		return tt.Elem()
+	case *types.Array:
+		return tt.Elem()
+	}
+	return nil
+}
+
+// selectedField returns the struct field selected by a field access,
+// or nil if v is not a field access.
+func selectedField(v ssa.Value) *types.Var {
+	switch f := v.(type) {
+	case *ssa.Field:
+		return structField(f.X.Type(), f.Field)
+	case *ssa.FieldAddr:
+		return structField(f.X.Type(), f.Field)
+	}
+	return nil
+}
+
+// structField returns the i-th field of a struct type, or of the struct type pointed to by t.
+func structField(t types.Type, i int) *types.Var {
+	st, ok := utils.Dereference(t).Underlying().(*types.Struct)
+	if !ok || i >= st.NumFields() {
+		return nil
 	}
+	return st.Field(i)
 }
@@ -39,6 +39,17 @@ func For(call ssa.CallInstruction) *Summary {
 	return nil
 }
 
+// AttributeKey returns the key argument of a call to a function that
+// constructs a structured logging attribute, or nil if the called function
+// is not known to construct one.
+func AttributeKey(call ssa.CallInstruction) ssa.Value {
+	i, ok := AttributeKeys[staticFuncName(call)]
+	if !ok || i >= len(call.Common().Args) {
+		return nil
+	}
+	return call.Common().Args[i]
+}
+
 // A Summary captures the behavior of a function with respect to taint
 // propagation. Specifically: given that at least one of the necessary
 // arguments is tainted, which arguments/return values become tainted?
@@ -54,6 +65,8 @@ func For(call ssa.CallInstruction) *Summary {
 // In English, this says that if the format string or the varargs slice are
 // tainted, then the Writer is tainted.
 // (In an actual summary, 0b110 should be written as second | third for readability.)
+// A flow into a field of a value, or from an individual variadic argument,
+// is described by an Extended summary instead.
 type Summary struct {
 	// IfTainted is a bitset which contains positions for parameters
 	// such that if one of these parameters is tainted, taint should
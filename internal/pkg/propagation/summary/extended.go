@@ -0,0 +1,173 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package summary
+
+import (
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-flow-levee/internal/pkg/utils"
+	"golang.org/x/tools/go/ssa"
+)
+
+// AnyElement is the Element of an Access that selects every element.
+const AnyElement = -1
+
+// An Access selects a part of a value: either a field of a struct,
+// or an element of a slice or array, such as an individual variadic argument.
+type Access struct {
+	// Field is the name of the selected field.
+	// It is empty if the Access selects an element.
+	Field string
+	// Element is the index of the selected element, or AnyElement.
+	// It is only meaningful if Field is empty.
+	Element int
+}
+
+func (a Access) String() string {
+	switch {
+	case a.Field != "":
+		return "." + a.Field
+	case a.Element == AnyElement:
+		return "[*]"
+	default:
+		return "[" + strconv.Itoa(a.Element) + "]"
+	}
+}
+
+// A Path designates an argument or a return value of a function,
+// or a part of one that is reached through a sequence of accesses.
+// As in a Summary, the receiver counts as the first argument.
+// For example, the Header field of the first returned value is At(0).Field("Header"),
+// and the second variadic argument of fmt.Printf is At(1).Element(1).
+type Path struct {
+	// Index is the position of the argument or return value.
+	Index int
+	// Accesses are applied in order, starting from the argument or return value.
+	Accesses []Access
+}
+
+// At returns the path designating the whole argument or return value at position i.
+func At(i int) Path {
+	return Path{Index: i}
+}
+
+// Positions returns the paths designating the whole arguments
+// or return values at the given positions.
+func Positions(indices []int) []Path {
+	var paths []Path
+	for _, i := range indices {
+		paths = append(paths, At(i))
+	}
+	return paths
+}
+
+// Field returns the path designating the named field of the value designated by p.
+func (p Path) Field(name string) Path {
+	return p.append(Access{Field: name})
+}
+
+// Element returns the path designating the i-th element of the value designated by p,
+// or every element if i is AnyElement.
+func (p Path) Element(i int) Path {
+	return p.append(Access{Element: i})
+}
+
+func (p Path) append(a Access) Path {
+	accesses := make([]Access, len(p.Accesses), len(p.Accesses)+1)
+	copy(accesses, p.Accesses)
+	return Path{Index: p.Index, Accesses: append(accesses, a)}
+}
+
+func (p Path) String() string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(p.Index))
+	for _, a := range p.Accesses {
+		b.WriteString(a.String())
+	}
+	return b.String()
+}
+
+// Extended is a summary whose positions are access paths, so that it can describe
+// taint flowing into a field of a value, or from an individual variadic argument.
+// If one of the IfTainted paths is tainted, then taint is propagated to the
+// TaintedArgs and TaintedRets paths. Unlike in a Summary, the number of
+// arguments is not limited.
+type Extended struct {
+	IfTainted   []Path
+	TaintedArgs []Path
+	TaintedRets []Path
+}
+
+// Extend converts a Summary into the equivalent Extended summary,
+// whose paths designate whole arguments and return values.
+func Extend(s Summary) Extended {
+	ext := Extended{
+		TaintedArgs: Positions(s.TaintedArgs),
+		TaintedRets: Positions(s.TaintedRets),
+	}
+	for i := 0; i < 64; i++ {
+		if s.IfTainted&(1<<uint(i)) != 0 {
+			ext.IfTainted = append(ext.IfTainted, At(i))
+		}
+	}
+	return ext
+}
+
+// ExtendedFor returns the extended summary for a given call if it exists,
+// or nil if no summary matches the called function.
+// Summaries in FuncSummaries and InterfaceFuncSummaries are converted automatically.
+func ExtendedFor(call ssa.CallInstruction) *Extended {
+	if ext, ok := ExtendedFuncSummaries[staticFuncName(call)]; ok {
+		return &ext
+	}
+	if summ := For(call); summ != nil {
+		ext := Extend(*summ)
+		return &ext
+	}
+	return nil
+}
+
+// ExtendedForFunction returns the extended summary of a function if it exists,
+// or nil if the function has no summary.
+// Since the function is known, summaries of interface methods are not considered.
+func ExtendedForFunction(fn *ssa.Function) *Extended {
+	name := fn.RelString(nil)
+	if ext, ok := ExtendedFuncSummaries[name]; ok {
+		return &ext
+	}
+	if summ, ok := FuncSummaries[name]; ok {
+		ext := Extend(summ)
+		return &ext
+	}
+	return nil
+}
+
+// SelectField returns the field with the given name of a struct type,
+// or of the struct type pointed to by t, or nil if there is no such field.
+// Fields promoted from embedded structs are not selected.
+func SelectField(t types.Type, name string) *types.Var {
+	st, ok := utils.Dereference(t).Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		if f := st.Field(i); f.Name() == name {
+			return f
+		}
+	}
+	return nil
+}
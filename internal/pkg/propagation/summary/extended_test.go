@@ -0,0 +1,62 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package summary
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPathString(t *testing.T) {
+	cases := []struct {
+		path Path
+		want string
+	}{
+		{At(0), "0"},
+		{At(1).Field("Header"), "1.Header"},
+		{At(2).Element(1), "2[1]"},
+		{At(2).Element(AnyElement).Field("URL").Field("RawQuery"), "2[*].URL.RawQuery"},
+	}
+	for _, tc := range cases {
+		if got := tc.path.String(); got != tc.want {
+			t.Errorf("String() = %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestPathDoesNotShareAccesses(t *testing.T) {
+	base := At(0).Field("A")
+	b, c := base.Field("B"), base.Field("C")
+	if b.String() != "0.A.B" || c.String() != "0.A.C" {
+		t.Errorf("got %v and %v, want 0.A.B and 0.A.C", b, c)
+	}
+}
+
+func TestExtend(t *testing.T) {
+	got := Extend(Summary{
+		IfTainted:   first | third | 1<<62,
+		TaintedArgs: []int{1},
+		TaintedRets: []int{0, 1},
+	})
+	want := Extended{
+		IfTainted:   []Path{At(0), At(2), At(62)},
+		TaintedArgs: []Path{At(1)},
+		TaintedRets: []Path{At(0), At(1)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("diff (-want +got):\n%s", diff)
+	}
+}
@@ -31,8 +31,41 @@ var fromFirstArgToFirstRet = Summary{
 	TaintedRets: []int{0},
 }
 
+var fromSecondArgToFirstRet = Summary{
+	IfTainted:   second,
+	TaintedRets: []int{0},
+}
+
+var fromSecondArgToFirstArg = Summary{
+	IfTainted:   second,
+	TaintedArgs: []int{0},
+}
+
+// Methods such as Encode(dst, src []byte) write into their first argument,
+// which follows the receiver.
+var fromThirdArgToSecondArg = Summary{
+	IfTainted:   third,
+	TaintedArgs: []int{1},
+}
+
+// The errors returned by parsing functions quote their input,
+// e.g. strconv.Atoi: parsing "hunter2": invalid syntax.
+var fromFirstArgToSecondRet = Summary{
+	IfTainted:   first,
+	TaintedRets: []int{1},
+}
+
+// Builder methods return a copy of their receiver, to which their
+// arguments have been attached, e.g. logger.With("key", value).
+var fromReceiverOrArgToFirstRet = Summary{
+	IfTainted:   first | second | third,
+	TaintedRets: []int{0},
+}
+
 // FuncSummaries contains summaries for regular functions
 // that could be called statically.
+// The summaries of standard library functions are checked against
+// the current toolchain by the tests of the verify package.
 var FuncSummaries = map[string]Summary{
 	// func Errorf(format string, a ...interface{}) error
 	"fmt.Errorf": {
@@ -63,36 +96,6 @@ var FuncSummaries = map[string]Summary{
 		IfTainted:   second,
 		TaintedArgs: []int{0},
 	},
-	// func Sscan(str string, a ...interface{}) (n int, err error)
-	"fmt.Sscan": {
-		IfTainted:   first,
-		TaintedArgs: []int{1},
-	},
-	// func Sscanln(str string, a ...interface{}) (n int, err error)
-	"fmt.Sscanln": {
-		IfTainted:   first,
-		TaintedArgs: []int{1},
-	},
-	// func Sscanf(str string, format string, a ...interface{}) (n int, err error)
-	"fmt.Sscanf": {
-		IfTainted:   first,
-		TaintedArgs: []int{2},
-	},
-	// func Fscan(r io.Reader, a ...interface{}) (n int, err error)
-	"fmt.Fscan": {
-		IfTainted:   first,
-		TaintedArgs: []int{1},
-	},
-	// func Fscanln(r io.Reader, a ...interface{}) (n int, err error)
-	"fmt.Fscanln": {
-		IfTainted:   first,
-		TaintedArgs: []int{1},
-	},
-	// func Fscanf(r io.Reader, format string, a ...interface{}) (n int, err error)
-	"fmt.Fscanf": {
-		IfTainted:   first,
-		TaintedArgs: []int{2},
-	},
 	// func New(text string) error
 	"errors.New": fromFirstArgToFirstRet,
 	// func Unwrap(err error) error
@@ -331,10 +334,16 @@ var FuncSummaries = map[string]Summary{
 	"io.MultiReader": fromFirstArgToFirstRet,
 	// func MultiWriter(writers ...Writer) Writer
 	"io.MultiWriter": fromFirstArgToFirstRet,
+	// func ReadAll(r Reader) ([]byte, error)
+	"io.ReadAll": fromFirstArgToFirstRet,
+	// func NopCloser(r Reader) ReadCloser
+	"io.NopCloser": fromFirstArgToFirstRet,
+	// func NewSectionReader(r ReaderAt, off int64, n int64) *SectionReader
+	"io.NewSectionReader": fromFirstArgToFirstRet,
 	// func (r *PipeReader) CloseWithError(err error) error
-	"(*io.PipeReader).CloseWithError": fromFirstArgToFirstRet,
+	"(*io.PipeReader).CloseWithError": fromSecondArgToFirstArg,
 	// func (w *PipeWriter) CloseWithError(err error) error
-	"(*io.PipeWriter).CloseWithError": fromFirstArgToFirstRet,
+	"(*io.PipeWriter).CloseWithError": fromSecondArgToFirstArg,
 	// func ReadAll(r io.Reader) ([]byte, error)
 	"io/ioutil.ReadAll": fromFirstArgToFirstRet,
 	// func NopCloser(r io.Reader) io.ReadCloser
@@ -383,6 +392,18 @@ var FuncSummaries = map[string]Summary{
 		IfTainted:   first,
 		TaintedRets: []int{1},
 	},
+	// func ScanRunes(data []byte, atEOF bool) (advance int, token []byte, err error)
+	"bufio.ScanRunes": {
+		IfTainted:   first,
+		TaintedRets: []int{1},
+	},
+	// func ScanBytes(data []byte, atEOF bool) (advance int, token []byte, err error)
+	"bufio.ScanBytes": {
+		IfTainted:   first,
+		TaintedRets: []int{1},
+	},
+	// func (b *Reader) Reset(r io.Reader)
+	"(*bufio.Reader).Reset": fromSecondArgToFirstArg,
 	// func WithValue(parent Context, key, val interface{}) Context
 	"context.WithValue": {
 		IfTainted:   first | second | third,
@@ -430,6 +451,16 @@ var FuncSummaries = map[string]Summary{
 	},
 	// func Unquote(s string) (string, error)
 	"strconv.Unquote": fromFirstArgToFirstRet,
+	// func Atoi(s string) (int, error)
+	"strconv.Atoi": fromFirstArgToSecondRet,
+	// func ParseBool(str string) (bool, error)
+	"strconv.ParseBool": fromFirstArgToSecondRet,
+	// func ParseFloat(s string, bitSize int) (float64, error)
+	"strconv.ParseFloat": fromFirstArgToSecondRet,
+	// func ParseInt(s string, base int, bitSize int) (i int64, err error)
+	"strconv.ParseInt": fromFirstArgToSecondRet,
+	// func ParseUint(s string, base int, bitSize int) (uint64, error)
+	"strconv.ParseUint": fromFirstArgToSecondRet,
 	// func Unmarshal(data []byte, v interface{}) error
 	"encoding/json.Unmarshal": {
 		IfTainted:   first | second,
@@ -480,21 +511,61 @@ var FuncSummaries = map[string]Summary{
 		TaintedArgs: []int{0},
 	},
 	// func (enc *Encoding) Encode(dst, src []byte)
-	"(*encoding/base64.Encoding).Encode": {
-		IfTainted:   second,
-		TaintedArgs: []int{0},
-	},
+	"(*encoding/base64.Encoding).Encode": fromThirdArgToSecondArg,
+	// func (enc *Encoding) EncodeToString(src []byte) string
+	"(*encoding/base64.Encoding).EncodeToString": fromSecondArgToFirstRet,
+	// func (enc *Encoding) DecodeString(s string) ([]byte, error)
+	"(*encoding/base64.Encoding).DecodeString": fromSecondArgToFirstRet,
+	// func (enc *Encoding) Decode(dst, src []byte) (n int, err error)
+	"(*encoding/base64.Encoding).Decode": fromThirdArgToSecondArg,
+	// func NewDecoder(enc *Encoding, r io.Reader) io.Reader
+	"encoding/base64.NewDecoder": fromSecondArgToFirstRet,
+	// func NewEncoder(enc *Encoding, w io.Writer) io.WriteCloser
+	"encoding/base64.NewEncoder": fromSecondArgToFirstRet,
+	// func (enc *Encoding) Encode(dst, src []byte)
+	"(*encoding/base32.Encoding).Encode": fromThirdArgToSecondArg,
 	// func (enc *Encoding) EncodeToString(src []byte) string
-	"(*encoding/base64.Encoding).EncodeToString": fromFirstArgToFirstRet,
+	"(*encoding/base32.Encoding).EncodeToString": fromSecondArgToFirstRet,
 	// func (enc *Encoding) DecodeString(s string) ([]byte, error)
-	"(*encoding/base64.Encoding).DecodeString": fromFirstArgToFirstRet,
+	"(*encoding/base32.Encoding).DecodeString": fromSecondArgToFirstRet,
 	// func (enc *Encoding) Decode(dst, src []byte) (n int, err error)
-	"(*encoding/base64.Encoding).Decode": {
+	"(*encoding/base32.Encoding).Decode": fromThirdArgToSecondArg,
+	// func NewDecoder(enc *Encoding, r io.Reader) io.Reader
+	"encoding/base32.NewDecoder": fromSecondArgToFirstRet,
+	// func NewEncoder(enc *Encoding, w io.Writer) io.WriteCloser
+	"encoding/base32.NewEncoder": fromSecondArgToFirstRet,
+	// func Encode(dst, src []byte) int
+	"encoding/hex.Encode": fromSecondArgToFirstArg,
+	// func EncodeToString(src []byte) string
+	"encoding/hex.EncodeToString": fromFirstArgToFirstRet,
+	// func Decode(dst, src []byte) (int, error)
+	"encoding/hex.Decode": {
 		IfTainted:   second,
 		TaintedArgs: []int{0},
+		TaintedRets: []int{1},
+	},
+	// func DecodeString(s string) ([]byte, error)
+	"encoding/hex.DecodeString": {
+		IfTainted:   first,
+		TaintedRets: []int{0, 1},
+	},
+	// func Dump(data []byte) string
+	"encoding/hex.Dump": fromFirstArgToFirstRet,
+	// func Dumper(w io.Writer) io.WriteCloser
+	"encoding/hex.Dumper": fromFirstArgToFirstRet,
+	// func NewDecoder(r io.Reader) io.Reader
+	"encoding/hex.NewDecoder": fromFirstArgToFirstRet,
+	// func NewEncoder(w io.Writer) io.Writer
+	"encoding/hex.NewEncoder": fromFirstArgToFirstRet,
+	// func EncodeToMemory(b *Block) []byte
+	"encoding/pem.EncodeToMemory": fromFirstArgToFirstRet,
+	// func Encode(out io.Writer, b *Block) error
+	"encoding/pem.Encode": fromSecondArgToFirstArg,
+	// func Decode(data []byte) (p *Block, rest []byte)
+	"encoding/pem.Decode": {
+		IfTainted:   first,
+		TaintedRets: []int{0, 1},
 	},
-	// func NewDecoder(enc *Encoding, r io.Reader) io.Reader
-	"encoding/base64.NewDecoder": fromFirstArgToFirstRet,
 	// func (m *Map) Load(key interface{}) (value interface{}, ok bool)
 	"(*sync.Map).Load": fromFirstArgToFirstRet,
 	// func (m *Map) Store(key, value interface{})
@@ -553,7 +624,7 @@ var FuncSummaries = map[string]Summary{
 	"text/template.HTMLEscaper": fromFirstArgToFirstRet,
 	// func JSEscape(w io.Writer, b []byte)
 	"text/template.JSEscape": {
-		IfTainted:   010,
+		IfTainted:   second,
 		TaintedArgs: []int{0},
 	},
 	// func JSEscapeString(s string) string
@@ -562,6 +633,14 @@ var FuncSummaries = map[string]Summary{
 	"text/template.JSEscaper": fromFirstArgToFirstRet,
 	// func URLQueryEscaper(args ...interface{}) string
 	"text/template.URLQueryEscaper": fromFirstArgToFirstRet,
+	// func (t *Template) Parse(text string) (*Template, error)
+	"(*text/template.Template).Parse": {
+		IfTainted:   first | second,
+		TaintedArgs: []int{0},
+		TaintedRets: []int{0},
+	},
+	// func Must(t *Template, err error) *Template
+	"text/template.Must": fromFirstArgToFirstRet,
 	// func (t *Template) ExecuteTemplate(wr io.Writer, name string, data interface{}) error
 	"(*html/template.Template).ExecuteTemplate": {
 		IfTainted:   fourth,
@@ -583,7 +662,7 @@ var FuncSummaries = map[string]Summary{
 	"html/template.HTMLEscaper": fromFirstArgToFirstRet,
 	// func JSEscape(w io.Writer, b []byte)
 	"html/template.JSEscape": {
-		IfTainted:   010,
+		IfTainted:   second,
 		TaintedArgs: []int{0},
 	},
 	// func JSEscapeString(s string) string
@@ -592,6 +671,18 @@ var FuncSummaries = map[string]Summary{
 	"html/template.JSEscaper": fromFirstArgToFirstRet,
 	// func URLQueryEscaper(args ...interface{}) string
 	"html/template.URLQueryEscaper": fromFirstArgToFirstRet,
+	// func (t *Template) Parse(text string) (*Template, error)
+	"(*html/template.Template).Parse": {
+		IfTainted:   first | second,
+		TaintedArgs: []int{0},
+		TaintedRets: []int{0},
+	},
+	// func Must(t *Template, err error) *Template
+	"html/template.Must": fromFirstArgToFirstRet,
+	// func EscapeString(s string) string
+	"html.EscapeString": fromFirstArgToFirstRet,
+	// func UnescapeString(s string) string
+	"html.UnescapeString": fromFirstArgToFirstRet,
 	// func Clean(path string) string
 	"path.Clean": fromFirstArgToFirstRet,
 	// func Split(path string) (dir, file string)
@@ -603,6 +694,10 @@ var FuncSummaries = map[string]Summary{
 	"path.Join": fromFirstArgToFirstRet,
 	// func Base(path string) string
 	"path.Base": fromFirstArgToFirstRet,
+	// func Dir(path string) string
+	"path.Dir": fromFirstArgToFirstRet,
+	// func Ext(path string) string
+	"path.Ext": fromFirstArgToFirstRet,
 	// func Clean(path string) string
 	"path/filepath.Clean": fromFirstArgToFirstRet,
 	// func ToSlash(path string) string
@@ -624,8 +719,20 @@ var FuncSummaries = map[string]Summary{
 	"path/filepath.Abs": fromFirstArgToFirstRet,
 	// func Base(path string) string
 	"path/filepath.Base": fromFirstArgToFirstRet,
+	// func Dir(path string) string
+	"path/filepath.Dir": fromFirstArgToFirstRet,
+	// func VolumeName(path string) string
+	"path/filepath.VolumeName": fromFirstArgToFirstRet,
+	// func Rel(basepath, targpath string) (string, error)
+	"path/filepath.Rel": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0, 1},
+	},
 	// func New(out io.Writer, prefix string, flag int) *Logger
-	"log.New": fromFirstArgToFirstRet,
+	"log.New": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
 	// func (l *Logger) SetOutput(w io.Writer)
 	"(*log.Logger).SetOutput": {
 		IfTainted:   second,
@@ -633,6 +740,417 @@ var FuncSummaries = map[string]Summary{
 	},
 	// func (l *Logger) Writer() io.Writer
 	"(*log.Logger).Writer": fromFirstArgToFirstRet,
+	// func NewReader(r io.Reader) (*Reader, error)
+	"compress/gzip.NewReader": fromFirstArgToFirstRet,
+	// func (z *Reader) Reset(r io.Reader) error
+	"(*compress/gzip.Reader).Reset": fromSecondArgToFirstArg,
+	// func NewWriter(w io.Writer) *Writer
+	"compress/gzip.NewWriter": fromFirstArgToFirstRet,
+	// func NewWriterLevel(w io.Writer, level int) (*Writer, error)
+	"compress/gzip.NewWriterLevel": fromFirstArgToFirstRet,
+	// func NewReader(r io.Reader) io.ReadCloser
+	"compress/flate.NewReader": fromFirstArgToFirstRet,
+	// func NewReaderDict(r io.Reader, dict []byte) io.ReadCloser
+	"compress/flate.NewReaderDict": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func NewWriter(w io.Writer, level int) (*Writer, error)
+	"compress/flate.NewWriter": fromFirstArgToFirstRet,
+	// func NewWriterDict(w io.Writer, level int, dict []byte) (*Writer, error)
+	"compress/flate.NewWriterDict": {
+		IfTainted:   first | third,
+		TaintedRets: []int{0},
+	},
+	// func NewReader(r io.Reader) (io.ReadCloser, error)
+	"compress/zlib.NewReader": fromFirstArgToFirstRet,
+	// func NewReaderDict(r io.Reader, dict []byte) (io.ReadCloser, error)
+	"compress/zlib.NewReaderDict": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func NewWriter(w io.Writer) *Writer
+	"compress/zlib.NewWriter": fromFirstArgToFirstRet,
+	// func NewWriterLevel(w io.Writer, level int) (*Writer, error)
+	"compress/zlib.NewWriterLevel": fromFirstArgToFirstRet,
+	// func NewWriterLevelDict(w io.Writer, level int, dict []byte) (*Writer, error)
+	"compress/zlib.NewWriterLevelDict": {
+		IfTainted:   first | third,
+		TaintedRets: []int{0},
+	},
+	// func NewReader(r io.Reader, order Order, litWidth int) io.ReadCloser
+	"compress/lzw.NewReader": fromFirstArgToFirstRet,
+	// func NewWriter(w io.Writer, order Order, litWidth int) io.WriteCloser
+	"compress/lzw.NewWriter": fromFirstArgToFirstRet,
+	// func NewReader(r io.Reader) io.Reader
+	"compress/bzip2.NewReader": fromFirstArgToFirstRet,
+	// Hashes are tainted by the data being hashed, but not by anything else:
+	// the digest of a low-entropy secret such as a password can be reversed
+	// by brute force, so it is as sensitive as the secret itself.
+	// func Sum(data []byte) [Size]byte
+	"crypto/md5.Sum": fromFirstArgToFirstRet,
+	// func Sum(data []byte) [Size]byte
+	"crypto/sha1.Sum": fromFirstArgToFirstRet,
+	// func Sum224(data []byte) [Size224]byte
+	"crypto/sha256.Sum224": fromFirstArgToFirstRet,
+	// func Sum256(data []byte) [Size]byte
+	"crypto/sha256.Sum256": fromFirstArgToFirstRet,
+	// func Sum384(data []byte) [Size384]byte
+	"crypto/sha512.Sum384": fromFirstArgToFirstRet,
+	// func Sum512(data []byte) [Size]byte
+	"crypto/sha512.Sum512": fromFirstArgToFirstRet,
+	// func Sum512_224(data []byte) [Size224]byte
+	"crypto/sha512.Sum512_224": fromFirstArgToFirstRet,
+	// func Sum512_256(data []byte) [Size256]byte
+	"crypto/sha512.Sum512_256": fromFirstArgToFirstRet,
+	// A MAC is also tainted by its key, which may be a secret.
+	// func New(h func() hash.Hash, key []byte) hash.Hash
+	"crypto/hmac.New": fromSecondArgToFirstRet,
+	// Ciphers are tainted by their key: anyone holding the key can recover
+	// the plaintext from the ciphertext, so both are as sensitive as the
+	// plaintext unless the key is properly protected. Encrypting and decrypting
+	// is summarized by the interface functions of cipher.Block, cipher.AEAD,
+	// cipher.Stream and cipher.BlockMode.
+	// func NewCipher(key []byte) (cipher.Block, error)
+	"crypto/aes.NewCipher": fromFirstArgToFirstRet,
+	// func NewCipher(key []byte) (cipher.Block, error)
+	"crypto/des.NewCipher": fromFirstArgToFirstRet,
+	// func NewTripleDESCipher(key []byte) (cipher.Block, error)
+	"crypto/des.NewTripleDESCipher": fromFirstArgToFirstRet,
+	// func NewCipher(key []byte) (*Cipher, error)
+	"crypto/rc4.NewCipher": fromFirstArgToFirstRet,
+	// func NewGCM(cipher Block) (AEAD, error)
+	"crypto/cipher.NewGCM": fromFirstArgToFirstRet,
+	// func NewGCMWithNonceSize(cipher Block, size int) (AEAD, error)
+	"crypto/cipher.NewGCMWithNonceSize": fromFirstArgToFirstRet,
+	// func NewGCMWithTagSize(cipher Block, tagSize int) (AEAD, error)
+	"crypto/cipher.NewGCMWithTagSize": fromFirstArgToFirstRet,
+	// Block modes and streams keep their IV, as well as their block.
+	// func NewCBCEncrypter(b Block, iv []byte) BlockMode
+	"crypto/cipher.NewCBCEncrypter": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func NewCBCDecrypter(b Block, iv []byte) BlockMode
+	"crypto/cipher.NewCBCDecrypter": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func NewCFBEncrypter(block Block, iv []byte) Stream
+	"crypto/cipher.NewCFBEncrypter": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func NewCFBDecrypter(block Block, iv []byte) Stream
+	"crypto/cipher.NewCFBDecrypter": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func NewCTR(block Block, iv []byte) Stream
+	"crypto/cipher.NewCTR": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func NewOFB(b Block, iv []byte) Stream
+	"crypto/cipher.NewOFB": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func EncryptOAEP(hash hash.Hash, random io.Reader, pub *PublicKey, msg []byte, label []byte) ([]byte, error)
+	"crypto/rsa.EncryptOAEP": {
+		IfTainted:   fourth,
+		TaintedRets: []int{0},
+	},
+	// func EncryptPKCS1v15(rand io.Reader, pub *PublicKey, msg []byte) ([]byte, error)
+	"crypto/rsa.EncryptPKCS1v15": {
+		IfTainted:   third,
+		TaintedRets: []int{0},
+	},
+	// func DecryptOAEP(hash hash.Hash, random io.Reader, priv *PrivateKey, ciphertext []byte, label []byte) ([]byte, error)
+	"crypto/rsa.DecryptOAEP": {
+		IfTainted:   third | fourth,
+		TaintedRets: []int{0},
+	},
+	// func DecryptPKCS1v15(rand io.Reader, priv *PrivateKey, ciphertext []byte) ([]byte, error)
+	"crypto/rsa.DecryptPKCS1v15": {
+		IfTainted:   second | third,
+		TaintedRets: []int{0},
+	},
+	// func (v Values) Get(key string) string
+	"(net/url.Values).Get": fromFirstArgToFirstRet,
+	// func (u *URL) Query() Values
+	"(*net/url.URL).Query": fromFirstArgToFirstRet,
+	// func (u *URL) EscapedPath() string
+	"(*net/url.URL).EscapedPath": fromFirstArgToFirstRet,
+	// func (u *URL) String() string
+	"(*net/url.URL).String": fromFirstArgToFirstRet,
+	// func (u *URL) Hostname() string
+	"(*net/url.URL).Hostname": fromFirstArgToFirstRet,
+	// func (u *URL) Port() string
+	"(*net/url.URL).Port": fromFirstArgToFirstRet,
+	// func (u *URL) RequestURI() string
+	"(*net/url.URL).RequestURI": fromFirstArgToFirstRet,
+	// func (u *URL) Redacted() string
+	"(*net/url.URL).Redacted": fromFirstArgToFirstRet,
+	// func (u *URL) ResolveReference(ref *URL) *URL
+	"(*net/url.URL).ResolveReference": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func (u *URL) Parse(ref string) (*URL, error)
+	"(*net/url.URL).Parse": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0, 1},
+	},
+	// func Parse(rawURL string) (*URL, error)
+	"net/url.Parse": {
+		IfTainted:   first,
+		TaintedRets: []int{0, 1},
+	},
+	// func ParseRequestURI(rawURL string) (*URL, error)
+	"net/url.ParseRequestURI": {
+		IfTainted:   first,
+		TaintedRets: []int{0, 1},
+	},
+	// func ParseQuery(query string) (Values, error)
+	"net/url.ParseQuery": {
+		IfTainted:   first,
+		TaintedRets: []int{0, 1},
+	},
+	// func QueryEscape(s string) string
+	"net/url.QueryEscape": fromFirstArgToFirstRet,
+	// func QueryUnescape(s string) (string, error)
+	"net/url.QueryUnescape": {
+		IfTainted:   first,
+		TaintedRets: []int{0, 1},
+	},
+	// func PathEscape(s string) string
+	"net/url.PathEscape": fromFirstArgToFirstRet,
+	// func PathUnescape(s string) (string, error)
+	"net/url.PathUnescape": {
+		IfTainted:   first,
+		TaintedRets: []int{0, 1},
+	},
+	// func (v Values) Encode() string
+	"(net/url.Values).Encode": fromFirstArgToFirstRet,
+	// func (v Values) Set(key, value string)
+	"(net/url.Values).Set": {
+		IfTainted:   second | third,
+		TaintedArgs: []int{0},
+	},
+	// func (v Values) Add(key, value string)
+	"(net/url.Values).Add": {
+		IfTainted:   second | third,
+		TaintedArgs: []int{0},
+	},
+	// func User(username string) *Userinfo
+	"net/url.User": fromFirstArgToFirstRet,
+	// func UserPassword(username, password string) *Userinfo
+	"net/url.UserPassword": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func (u *Userinfo) Username() string
+	"(*net/url.Userinfo).Username": fromFirstArgToFirstRet,
+	// func (u *Userinfo) Password() (string, bool)
+	"(*net/url.Userinfo).Password": fromFirstArgToFirstRet,
+	// func (u *Userinfo) String() string
+	"(*net/url.Userinfo).String": fromFirstArgToFirstRet,
+	// func (h Header) Get(key string) string
+	"(net/http.Header).Get": fromFirstArgToFirstRet,
+	// func (h Header) Values(key string) []string
+	"(net/http.Header).Values": fromFirstArgToFirstRet,
+	// func (r *Request) FormValue(key string) string
+	"(*net/http.Request).FormValue": fromFirstArgToFirstRet,
+	// func (r *Request) PostFormValue(key string) string
+	"(*net/http.Request).PostFormValue": fromFirstArgToFirstRet,
+	// func String(key, value string) Attr
+	"log/slog.String": fromSecondArgToFirstRet,
+	// func Any(key string, value any) Attr
+	"log/slog.Any": fromSecondArgToFirstRet,
+	// func Group(key string, args ...any) Attr
+	"log/slog.Group": fromSecondArgToFirstRet,
+	// func Bool(key string, v bool) Attr
+	"log/slog.Bool": fromSecondArgToFirstRet,
+	// func Duration(key string, v time.Duration) Attr
+	"log/slog.Duration": fromSecondArgToFirstRet,
+	// func Float64(key string, v float64) Attr
+	"log/slog.Float64": fromSecondArgToFirstRet,
+	// func Int(key string, value int) Attr
+	"log/slog.Int": fromSecondArgToFirstRet,
+	// func Int64(key string, value int64) Attr
+	"log/slog.Int64": fromSecondArgToFirstRet,
+	// func Time(key string, v time.Time) Attr
+	"log/slog.Time": fromSecondArgToFirstRet,
+	// func Uint64(key string, v uint64) Attr
+	"log/slog.Uint64": fromSecondArgToFirstRet,
+	// func StringValue(value string) Value
+	"log/slog.StringValue": fromFirstArgToFirstRet,
+	// func AnyValue(v any) Value
+	"log/slog.AnyValue": fromFirstArgToFirstRet,
+	// func GroupValue(as ...Attr) Value
+	"log/slog.GroupValue": fromFirstArgToFirstRet,
+	// func String(key string, val string) Field
+	"go.uber.org/zap.String": fromSecondArgToFirstRet,
+	// func Strings(key string, ss []string) Field
+	"go.uber.org/zap.Strings": fromSecondArgToFirstRet,
+	// func Stringp(key string, val *string) Field
+	"go.uber.org/zap.Stringp": fromSecondArgToFirstRet,
+	// func ByteString(key string, val []byte) Field
+	"go.uber.org/zap.ByteString": fromSecondArgToFirstRet,
+	// func Binary(key string, val []byte) Field
+	"go.uber.org/zap.Binary": fromSecondArgToFirstRet,
+	// func Stringer(key string, val fmt.Stringer) Field
+	"go.uber.org/zap.Stringer": fromSecondArgToFirstRet,
+	// func Any(key string, value interface{}) Field
+	"go.uber.org/zap.Any": fromSecondArgToFirstRet,
+	// func Reflect(key string, val interface{}) Field
+	"go.uber.org/zap.Reflect": fromSecondArgToFirstRet,
+	// func Object(key string, val zapcore.ObjectMarshaler) Field
+	"go.uber.org/zap.Object": fromSecondArgToFirstRet,
+	// func Error(err error) Field
+	"go.uber.org/zap.Error": fromFirstArgToFirstRet,
+	// func NamedError(key string, err error) Field
+	"go.uber.org/zap.NamedError": fromSecondArgToFirstRet,
+	// func Bool(key string, val bool) Field
+	"go.uber.org/zap.Bool": fromSecondArgToFirstRet,
+	// func Float64(key string, val float64) Field
+	"go.uber.org/zap.Float64": fromSecondArgToFirstRet,
+	// func Int(key string, val int) Field
+	"go.uber.org/zap.Int": fromSecondArgToFirstRet,
+	// func Int64(key string, val int64) Field
+	"go.uber.org/zap.Int64": fromSecondArgToFirstRet,
+	// func Uint64(key string, val uint64) Field
+	"go.uber.org/zap.Uint64": fromSecondArgToFirstRet,
+	// func WithField(key string, value interface{}) *Entry
+	"github.com/sirupsen/logrus.WithField": fromSecondArgToFirstRet,
+	// func WithFields(fields Fields) *Entry
+	"github.com/sirupsen/logrus.WithFields": fromFirstArgToFirstRet,
+	// func WithError(err error) *Entry
+	"github.com/sirupsen/logrus.WithError": fromFirstArgToFirstRet,
+	// func NewEntry(logger *Logger) *Entry
+	"github.com/sirupsen/logrus.NewEntry": fromFirstArgToFirstRet,
+	// func (l *Logger) With(args ...any) *Logger
+	"(*log/slog.Logger).With": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func (l *Logger) WithGroup(name string) *Logger
+	"(*log/slog.Logger).WithGroup": {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// func (log *Logger) With(fields ...Field) *Logger
+	"(*go.uber.org/zap.Logger).With": fromReceiverOrArgToFirstRet,
+	// func (log *Logger) Named(s string) *Logger
+	"(*go.uber.org/zap.Logger).Named": fromReceiverOrArgToFirstRet,
+	// func (log *Logger) Sugar() *SugaredLogger
+	"(*go.uber.org/zap.Logger).Sugar": fromFirstArgToFirstRet,
+	// func (s *SugaredLogger) With(args ...interface{}) *SugaredLogger
+	"(*go.uber.org/zap.SugaredLogger).With": fromReceiverOrArgToFirstRet,
+	// func (s *SugaredLogger) Named(name string) *SugaredLogger
+	"(*go.uber.org/zap.SugaredLogger).Named": fromReceiverOrArgToFirstRet,
+	// func (s *SugaredLogger) Desugar() *Logger
+	"(*go.uber.org/zap.SugaredLogger).Desugar": fromFirstArgToFirstRet,
+	// func (entry *Entry) WithField(key string, value interface{}) *Entry
+	"(*github.com/sirupsen/logrus.Entry).WithField": fromReceiverOrArgToFirstRet,
+	// func (entry *Entry) WithFields(fields Fields) *Entry
+	"(*github.com/sirupsen/logrus.Entry).WithFields": fromReceiverOrArgToFirstRet,
+	// func (entry *Entry) WithError(err error) *Entry
+	"(*github.com/sirupsen/logrus.Entry).WithError": fromReceiverOrArgToFirstRet,
+	// func (logger *Logger) WithField(key string, value interface{}) *Entry
+	"(*github.com/sirupsen/logrus.Logger).WithField": fromReceiverOrArgToFirstRet,
+	// func (logger *Logger) WithFields(fields Fields) *Entry
+	"(*github.com/sirupsen/logrus.Logger).WithFields": fromReceiverOrArgToFirstRet,
+	// func (logger *Logger) WithError(err error) *Entry
+	"(*github.com/sirupsen/logrus.Logger).WithError": fromReceiverOrArgToFirstRet,
+}
+
+// ExtendedFuncSummaries contains summaries that need access paths,
+// e.g. because taint flows into a field of a value,
+// or into the values pointed to by variadic arguments.
+// The keys have the same form as the keys of FuncSummaries.
+var ExtendedFuncSummaries = map[string]Extended{
+	// func Sscan(str string, a ...interface{}) (n int, err error)
+	"fmt.Sscan": {
+		IfTainted:   []Path{At(0)},
+		TaintedArgs: []Path{At(1).Element(AnyElement)},
+	},
+	// func Sscanln(str string, a ...interface{}) (n int, err error)
+	"fmt.Sscanln": {
+		IfTainted:   []Path{At(0)},
+		TaintedArgs: []Path{At(1).Element(AnyElement)},
+	},
+	// func Sscanf(str string, format string, a ...interface{}) (n int, err error)
+	"fmt.Sscanf": {
+		IfTainted:   []Path{At(0)},
+		TaintedArgs: []Path{At(2).Element(AnyElement)},
+	},
+	// func Fscan(r io.Reader, a ...interface{}) (n int, err error)
+	"fmt.Fscan": {
+		IfTainted:   []Path{At(0)},
+		TaintedArgs: []Path{At(1).Element(AnyElement)},
+	},
+	// func Fscanln(r io.Reader, a ...interface{}) (n int, err error)
+	"fmt.Fscanln": {
+		IfTainted:   []Path{At(0)},
+		TaintedArgs: []Path{At(1).Element(AnyElement)},
+	},
+	// func Fscanf(r io.Reader, format string, a ...interface{}) (n int, err error)
+	"fmt.Fscanf": {
+		IfTainted:   []Path{At(0)},
+		TaintedArgs: []Path{At(2).Element(AnyElement)},
+	},
+	// func (r *Request) SetBasicAuth(username, password string)
+	"(*net/http.Request).SetBasicAuth": {
+		IfTainted:   []Path{At(1), At(2)},
+		TaintedArgs: []Path{At(0).Field("Header")},
+	},
+	// func (r *Request) AddCookie(c *Cookie)
+	"(*net/http.Request).AddCookie": {
+		IfTainted:   []Path{At(1)},
+		TaintedArgs: []Path{At(0).Field("Header")},
+	},
+}
+
+// AttributeKeys contains the position of the key argument of functions
+// that construct structured logging attributes, e.g. slog.String(key, value).
+var AttributeKeys = map[string]int{
+	"log/slog.String":                      0,
+	"log/slog.Any":                         0,
+	"log/slog.Group":                       0,
+	"log/slog.Bool":                        0,
+	"log/slog.Duration":                    0,
+	"log/slog.Float64":                     0,
+	"log/slog.Int":                         0,
+	"log/slog.Int64":                       0,
+	"log/slog.Time":                        0,
+	"log/slog.Uint64":                      0,
+	"go.uber.org/zap.String":               0,
+	"go.uber.org/zap.Strings":              0,
+	"go.uber.org/zap.Stringp":              0,
+	"go.uber.org/zap.ByteString":           0,
+	"go.uber.org/zap.Binary":               0,
+	"go.uber.org/zap.Stringer":             0,
+	"go.uber.org/zap.Any":                  0,
+	"go.uber.org/zap.Reflect":              0,
+	"go.uber.org/zap.Object":               0,
+	"go.uber.org/zap.NamedError":           0,
+	"go.uber.org/zap.Bool":                 0,
+	"go.uber.org/zap.Float64":              0,
+	"go.uber.org/zap.Int":                  0,
+	"go.uber.org/zap.Int64":                0,
+	"go.uber.org/zap.Uint64":               0,
+	"github.com/sirupsen/logrus.WithField": 0,
+	// For methods, the receiver is the first argument.
+	"(*github.com/sirupsen/logrus.Entry).WithField":  1,
+	"(*github.com/sirupsen/logrus.Logger).WithField": 1,
+}
+
+// AttributeMapTypes contains map types whose keys are the names of
+// structured logging attributes, e.g. logrus.Fields{key: value}.
+var AttributeMapTypes = map[string]bool{
+	"github.com/sirupsen/logrus.Fields": true,
 }
 
 // funcKey represents an interface function by its name and its signature.
@@ -729,6 +1247,95 @@ var InterfaceFuncSummaries = map[funcKey]Summary{
 		IfTainted:   first,
 		TaintedRets: []int{0},
 	},
+	// type encoding.TextMarshaler interface {
+	//  MarshalText() (text []byte, err error)
+	// }
+	{"MarshalText", "()([]byte,error)"}: {
+		IfTainted:   first,
+		TaintedRets: []int{0},
+	},
+	// type encoding.TextUnmarshaler interface {
+	//  UnmarshalText(text []byte) error
+	// }
+	{"UnmarshalText", "([]byte)(error)"}: {
+		IfTainted:   second,
+		TaintedArgs: []int{0},
+	},
+	// type encoding.BinaryMarshaler interface {
+	//  MarshalBinary() (data []byte, err error)
+	// }
+	{"MarshalBinary", "()([]byte,error)"}: {
+		IfTainted:   first,
+		TaintedRets: []int{0},
+	},
+	// type encoding.BinaryUnmarshaler interface {
+	//  UnmarshalBinary(data []byte) error
+	// }
+	{"UnmarshalBinary", "([]byte)(error)"}: {
+		IfTainted:   second,
+		TaintedArgs: []int{0},
+	},
+	// type json.Marshaler interface {
+	//  MarshalJSON() ([]byte, error)
+	// }
+	{"MarshalJSON", "()([]byte,error)"}: {
+		IfTainted:   first,
+		TaintedRets: []int{0},
+	},
+	// type json.Unmarshaler interface {
+	//  UnmarshalJSON([]byte) error
+	// }
+	{"UnmarshalJSON", "([]byte)(error)"}: {
+		IfTainted:   second,
+		TaintedArgs: []int{0},
+	},
+	// type hash.Hash interface {
+	//  Sum(b []byte) []byte
+	// }
+	{"Sum", "([]byte)([]byte)"}: {
+		IfTainted:   first | second,
+		TaintedRets: []int{0},
+	},
+	// type cipher.Block interface {
+	//  Encrypt(dst, src []byte)
+	//  Decrypt(dst, src []byte)
+	// }
+	{"Encrypt", "([]byte,[]byte)()"}: {
+		IfTainted:   first | third,
+		TaintedArgs: []int{1},
+	},
+	{"Decrypt", "([]byte,[]byte)()"}: {
+		IfTainted:   first | third,
+		TaintedArgs: []int{1},
+	},
+	// type cipher.Stream interface {
+	//  XORKeyStream(dst, src []byte)
+	// }
+	{"XORKeyStream", "([]byte,[]byte)()"}: {
+		IfTainted:   first | third,
+		TaintedArgs: []int{1},
+	},
+	// type cipher.BlockMode interface {
+	//  CryptBlocks(dst, src []byte)
+	// }
+	{"CryptBlocks", "([]byte,[]byte)()"}: {
+		IfTainted:   first | third,
+		TaintedArgs: []int{1},
+	},
+	// type cipher.AEAD interface {
+	//  Seal(dst, nonce, plaintext, additionalData []byte) []byte
+	//  Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error)
+	// }
+	{"Seal", "([]byte,[]byte,[]byte,[]byte)([]byte)"}: {
+		IfTainted:   first | fourth,
+		TaintedArgs: []int{1},
+		TaintedRets: []int{0},
+	},
+	{"Open", "([]byte,[]byte,[]byte,[]byte)([]byte,error)"}: {
+		IfTainted:   first | fourth,
+		TaintedArgs: []int{1},
+		TaintedRets: []int{0},
+	},
 	// type context.Context interface {
 	//  Err() error
 	//  Value(key interface{}) interface{}
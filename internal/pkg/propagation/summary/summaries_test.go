@@ -0,0 +1,90 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package summary
+
+import (
+	"go/importer"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+// toolchainDependentKeys are the keys of InterfaceFuncSummaries that only
+// resolve with some toolchains.
+var toolchainDependentKeys = map[funcKey]bool{
+	{"Value", "(interface{})(interface{})"}: true,
+}
+
+// TestInterfaceSummaryKeysResolve checks that the keys of InterfaceFuncSummaries
+// are the names and signatures of methods of standard library interfaces or types,
+// and that their summaries fit these signatures.
+// The keys of FuncSummaries are checked by the verify package.
+func TestInterfaceSummaryKeysResolve(t *testing.T) {
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil)
+	methods := map[funcKey]*types.Signature{}
+	addMethod := func(m *types.Func) {
+		sig := m.Type().(*types.Signature)
+		methods[funcKey{m.Name(), sigTypeString(sig)}] = sig
+	}
+	addMethod(types.Universe.Lookup("error").Type().Underlying().(*types.Interface).Method(0))
+	for _, path := range []string{"bytes", "context", "crypto/cipher", "encoding", "encoding/json", "fmt", "hash", "io", "os"} {
+		pkg, err := imp.Import(path)
+		if err != nil {
+			t.Fatalf("importing %s: %v", path, err)
+		}
+		for _, name := range pkg.Scope().Names() {
+			tn, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			if iface, ok := tn.Type().Underlying().(*types.Interface); ok {
+				for i := 0; i < iface.NumMethods(); i++ {
+					addMethod(iface.Method(i))
+				}
+			} else if named, ok := tn.Type().(*types.Named); ok {
+				for i := 0; i < named.NumMethods(); i++ {
+					addMethod(named.Method(i))
+				}
+			}
+		}
+	}
+
+	for key, s := range InterfaceFuncSummaries {
+		sig, ok := methods[key]
+		if !ok && toolchainDependentKeys[key] {
+			t.Logf("%s%s is not a method of a standard library interface with this toolchain", key.name, key.signature)
+			continue
+		}
+		if !ok {
+			t.Errorf("%s%s is not a method of a standard library interface or type", key.name, key.signature)
+			continue
+		}
+		// The receiver counts as the first parameter.
+		params := sig.Params().Len() + 1
+		if s.IfTainted>>uint(params) != 0 {
+			t.Errorf("%s%s: IfTainted is %#b, but there are only %d parameters", key.name, key.signature, s.IfTainted, params)
+		}
+		for _, a := range s.TaintedArgs {
+			if a >= params {
+				t.Errorf("%s%s: TaintedArgs contains %d, but there are only %d parameters", key.name, key.signature, a, params)
+			}
+		}
+		for _, r := range s.TaintedRets {
+			if r >= sig.Results().Len() {
+				t.Errorf("%s%s: TaintedRets contains %d, but there are only %d results", key.name, key.signature, r, sig.Results().Len())
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"fmt"
+
+	"github.com/google/go-flow-levee/internal/pkg/propagation/summary"
+)
+
+// Compare compares a summary with the observed behavior of its function.
+// Missed flows copy an input to an output, but are not described by the summary,
+// so that taint would be lost. Outputs that change with an input without
+// containing it, e.g. hashes or values looked up by a key, are not missed flows,
+// but they are accepted as observations of the flows described by the summary.
+// Unobserved flows are described by the summary, but were not observed;
+// since not every behavior of a function can be observed with a single set
+// of inputs, they only suggest that the summary may be imprecise.
+func Compare(s summary.Summary, obs Observation) (missed, unobserved []string) {
+	observedArgs, observedRets := map[int]bool{}, map[int]bool{}
+	observedFrom := map[int]bool{}
+	for _, f := range obs.Flows {
+		ifTainted := s.IfTainted&(1<<uint(f.From)) != 0
+		for _, a := range f.Args {
+			observedArgs[a] = true
+			observedFrom[f.From] = true
+		}
+		for _, r := range f.Rets {
+			observedRets[r] = true
+			observedFrom[f.From] = true
+		}
+		for _, a := range f.CopiedArgs {
+			if !ifTainted || !contains(s.TaintedArgs, a) {
+				missed = append(missed, fmt.Sprintf("argument %d taints argument %d", f.From, a))
+			}
+		}
+		for _, r := range f.CopiedRets {
+			if !ifTainted || !contains(s.TaintedRets, r) {
+				missed = append(missed, fmt.Sprintf("argument %d taints result %d", f.From, r))
+			}
+		}
+	}
+
+	for _, i := range obs.Varied {
+		if s.IfTainted&(1<<uint(i)) != 0 && !observedFrom[i] {
+			unobserved = append(unobserved, fmt.Sprintf("argument %d does not taint any output", i))
+		}
+	}
+	for _, a := range s.TaintedArgs {
+		if !observedArgs[a] {
+			unobserved = append(unobserved, fmt.Sprintf("argument %d is not tainted", a))
+		}
+	}
+	for _, r := range s.TaintedRets {
+		if !observedRets[r] {
+			unobserved = append(unobserved, fmt.Sprintf("result %d is not tainted", r))
+		}
+	}
+	return missed, unobserved
+}
+
+func contains(positions []int, p int) bool {
+	for _, q := range positions {
+		if q == p {
+			return true
+		}
+	}
+	return false
+}
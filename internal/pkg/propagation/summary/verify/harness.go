@@ -0,0 +1,546 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/aes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/scanner"
+	"text/tabwriter"
+	"text/template"
+	"unicode"
+)
+
+// An Observation records how a function propagates its inputs, as observed by calling it.
+type Observation struct {
+	Key string
+	// Varied lists the positions of the inputs that were given marker values.
+	Varied []int `json:",omitempty"`
+	// Flows lists the outputs that changed when the marker of an input changed.
+	Flows []Flow `json:",omitempty"`
+	// Untested explains why the function could not be called, if it could not.
+	Untested string `json:",omitempty"`
+}
+
+// A Flow records that changing the input at position From changed
+// some arguments and results of a call. Positions are numbered as in summaries,
+// i.e. the receiver of a method counts as its first argument.
+type Flow struct {
+	From int
+	Args []int `json:",omitempty"`
+	Rets []int `json:",omitempty"`
+	// CopiedArgs and CopiedRets are the outputs that contain the marker itself,
+	// rather than e.g. a hash of the marker, or a value looked up by the marker.
+	CopiedArgs []int `json:",omitempty"`
+	CopiedRets []int `json:",omitempty"`
+}
+
+// marker returns the marker of the input at a position, e.g. "A02-Levee-Marker".
+// Markers differ from their first byte, so that prefixes of markers differ too.
+// They have 16 bytes, which makes them valid AES keys, and they are canonical
+// MIME header keys, so that they can be looked up in an http.Header.
+func marker(position int, variant bool) string {
+	v := 'A'
+	if variant {
+		v = 'B'
+	}
+	return fmt.Sprintf("%c%02d-Levee-Marker", v, position)
+}
+
+// Observe calls a function once with a marker value for each input, then once more
+// per input, with a different marker for that input only. An argument or result
+// contains the marker of an input if it changes when the marker changes, which also
+// detects markers that are encoded, compressed or hashed.
+// Arguments are only observed if they can be mutated, e.g. pointers or slices,
+// and results are only observed if they can contain a marker, e.g. not integers.
+// Outputs that change between calls with the same inputs are ignored.
+// If the call panics, e.g. because a destination slice is too short,
+// it is retried with a longer slice for the first byte slice input.
+func Observe(key string, fn interface{}) Observation {
+	obs := Observation{Key: key}
+	c := newCaller(reflect.ValueOf(fn))
+	if err := c.check(); err != nil {
+		obs.Untested = err.Error()
+		return obs
+	}
+
+	base, err := c.call(-1)
+	if err != nil {
+		for i := 0; i < c.typ.NumIn(); i++ {
+			if t := c.typ.In(i); t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+				c.padded = i
+				base, err = c.call(-1)
+				break
+			}
+		}
+	}
+	if err != nil {
+		obs.Untested = err.Error()
+		return obs
+	}
+	again, err := c.call(-1)
+	if err != nil {
+		obs.Untested = err.Error()
+		return obs
+	}
+
+	nIn := c.typ.NumIn()
+	for i := 0; i < nIn; i++ {
+		if !c.varies[i] {
+			continue
+		}
+		obs.Varied = append(obs.Varied, i)
+		out, err := c.call(i)
+		if err != nil {
+			continue
+		}
+		flow := Flow{From: i}
+		for j := range out {
+			if j == i || base[j] == nil || out[j] == nil || *base[j] != *again[j] || *base[j] == *out[j] {
+				continue
+			}
+			copied := strings.Contains(*out[j], marker(i, true))
+			if j < nIn {
+				flow.Args = append(flow.Args, j)
+				if copied {
+					flow.CopiedArgs = append(flow.CopiedArgs, j)
+				}
+			} else {
+				flow.Rets = append(flow.Rets, j-nIn)
+				if copied {
+					flow.CopiedRets = append(flow.CopiedRets, j-nIn)
+				}
+			}
+		}
+		if len(flow.Args) > 0 || len(flow.Rets) > 0 {
+			obs.Flows = append(obs.Flows, flow)
+		}
+	}
+	return obs
+}
+
+// A caller calls a function with marker values.
+type caller struct {
+	fn  reflect.Value
+	typ reflect.Type
+	// varies records which inputs carry markers.
+	varies []bool
+	// padded is the position of an input whose bytes are followed by padding, if any.
+	// Otherwise, it is -1.
+	padded  int
+	padding int
+}
+
+func newCaller(fn reflect.Value) *caller {
+	return &caller{fn: fn, typ: fn.Type(), varies: make([]bool, fn.Type().NumIn()), padded: -1}
+}
+
+// check determines whether values can be constructed for all of the inputs.
+func (c *caller) check() error {
+	for i := range c.varies {
+		_, varies, err := c.value(c.inType(i), marker(i, false))
+		if err != nil {
+			return err
+		}
+		c.varies[i] = varies
+	}
+	return nil
+}
+
+// inType returns the type of an input, or the type of the elements of a variadic input.
+func (c *caller) inType(i int) reflect.Type {
+	t := c.typ.In(i)
+	if c.typ.IsVariadic() && i == c.typ.NumIn()-1 {
+		return t.Elem()
+	}
+	return t
+}
+
+// call calls the function with the markers of the inputs, except that the input
+// at position changed has a different marker. It returns the rendering of each
+// argument and result that is observed, or nil for those that are not.
+func (c *caller) call(changed int) (out []*string, err error) {
+	nIn := c.typ.NumIn()
+	args := make([]reflect.Value, nIn)
+	for i := range args {
+		c.padding = 0
+		if i == c.padded {
+			c.padding = 64
+		}
+		v, _, err := c.value(c.inType(i), marker(i, i == changed))
+		if err != nil {
+			return nil, err
+		}
+		// Variadic inputs have two elements, e.g. to form a pair for strings.NewReplacer.
+		if c.typ.IsVariadic() && i == nIn-1 {
+			s := reflect.MakeSlice(c.typ.In(i), 2, 2)
+			s.Index(0).Set(v)
+			s.Index(1).Set(v)
+			v = s
+		}
+		args[i] = v
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			out, err = nil, fmt.Errorf("the call panics: %v", r)
+		}
+	}()
+	var rets []reflect.Value
+	if c.typ.IsVariadic() {
+		rets = c.fn.CallSlice(args)
+	} else {
+		rets = c.fn.Call(args)
+	}
+
+	for _, a := range args {
+		out = append(out, renderIf(a, isMutable(a.Type())))
+	}
+	for _, r := range rets {
+		out = append(out, renderIf(r, canContainMarker(r.Type())))
+	}
+	return out, nil
+}
+
+func renderIf(v reflect.Value, observed bool) *string {
+	if !observed {
+		return nil
+	}
+	r := &renderer{seen: map[uintptr]bool{}}
+	r.render(v, 0)
+	s := r.b.String()
+	return &s
+}
+
+func isMutable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// canContainMarker determines whether a value of a type may contain a marker.
+// As in summaries, numbers and booleans are not considered to carry their inputs.
+func canContainMarker(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	}
+	return true
+}
+
+// value constructs a value of a type that contains a marker, if possible.
+// Values that cannot contain the marker, such as numbers, are constant.
+func (c *caller) value(t reflect.Type, m string) (reflect.Value, bool, error) {
+	if ctor, ok := constructors[t]; ok {
+		v, varies := ctor(m)
+		return reflect.ValueOf(v), varies, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(m).Convert(t), true, nil
+	case reflect.Bool:
+		return reflect.Zero(t), false, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		// 2 is a valid base, size and count for most functions.
+		return reflect.ValueOf(2).Convert(t), false, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			b := append([]byte(m), make([]byte, c.padding)...)
+			return reflect.ValueOf(b).Convert(t), true, nil
+		}
+		// Slices have two elements, e.g. so that strings.Join uses its separator.
+		elem, varies, err := c.value(t.Elem(), m)
+		if err != nil {
+			return reflect.Value{}, false, err
+		}
+		s := reflect.MakeSlice(t, 2, 2)
+		s.Index(0).Set(elem)
+		s.Index(1).Set(elem)
+		return s, varies, nil
+	case reflect.Map:
+		// The map also has an entry for the marker of each input,
+		// so that looking up another input finds the marker of the map.
+		if t.Key().Kind() != reflect.String {
+			break
+		}
+		elem, varies, err := c.value(t.Elem(), m)
+		if err != nil {
+			return reflect.Value{}, false, err
+		}
+		mv := reflect.MakeMap(t)
+		for i := 0; i < c.typ.NumIn(); i++ {
+			mv.SetMapIndex(reflect.ValueOf(marker(i, false)).Convert(t.Key()), elem)
+		}
+		mv.SetMapIndex(reflect.ValueOf(m).Convert(t.Key()), elem)
+		return mv, varies, nil
+	case reflect.Ptr:
+		elem, varies, err := c.value(t.Elem(), m)
+		if err != nil {
+			return reflect.Value{}, false, err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(elem)
+		return p, varies, nil
+	case reflect.Struct:
+		return reflect.Zero(t), false, nil
+	case reflect.Interface:
+		for _, ctor := range implementations {
+			v, varies := ctor(m)
+			if reflect.TypeOf(v).Implements(t) {
+				return reflect.ValueOf(v), varies, nil
+			}
+		}
+	}
+	return reflect.Value{}, false, fmt.Errorf("no value can be constructed for type %s", t)
+}
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+// constructors construct values of specific types from a marker.
+// They return whether the value contains the marker.
+var constructors = map[reflect.Type]func(m string) (interface{}, bool){
+	reflect.TypeOf(new(bytes.Buffer)):   func(m string) (interface{}, bool) { return bytes.NewBufferString(m), true },
+	reflect.TypeOf(new(bytes.Reader)):   func(m string) (interface{}, bool) { return bytes.NewReader([]byte(m)), true },
+	reflect.TypeOf(new(strings.Reader)): func(m string) (interface{}, bool) { return strings.NewReader(m), true },
+	reflect.TypeOf(new(strings.Builder)): func(m string) (interface{}, bool) {
+		b := new(strings.Builder)
+		b.WriteString(m)
+		return b, true
+	},
+	// The markers of other inputs all start with "Levee", which is replaced by the marker.
+	reflect.TypeOf(new(strings.Replacer)): func(m string) (interface{}, bool) { return strings.NewReplacer("Levee", m), true },
+	reflect.TypeOf(new(bufio.Reader)):     func(m string) (interface{}, bool) { return bufio.NewReader(strings.NewReader(m)), true },
+	reflect.TypeOf(new(bufio.Scanner)):    func(m string) (interface{}, bool) { return bufio.NewScanner(strings.NewReader(m)), true },
+	reflect.TypeOf(new(bufio.Writer)): func(m string) (interface{}, bool) {
+		w := bufio.NewWriter(new(bytes.Buffer))
+		w.WriteString(m)
+		return w, true
+	},
+	reflect.TypeOf(new(url.URL)): func(m string) (interface{}, bool) {
+		return &url.URL{Scheme: "https", Host: "example.com", Path: "/" + m, RawQuery: "q=" + m}, true
+	},
+	reflect.TypeOf(new(http.Request)): func(m string) (interface{}, bool) {
+		return &http.Request{Form: url.Values{"q": {m}}, PostForm: url.Values{"q": {m}}, Header: http.Header{"Q": {m}}}, true
+	},
+	reflect.TypeOf(new(template.Template)):     func(m string) (interface{}, bool) { return template.New(m), true },
+	reflect.TypeOf(new(htmltemplate.Template)): func(m string) (interface{}, bool) { return htmltemplate.New(m), true },
+	reflect.TypeOf(new(json.Decoder)): func(m string) (interface{}, bool) {
+		return json.NewDecoder(strings.NewReader(strconv.Quote(m))), true
+	},
+	reflect.TypeOf(new(json.Encoder)):    func(m string) (interface{}, bool) { return json.NewEncoder(new(bytes.Buffer)), false },
+	reflect.TypeOf(new(base64.Encoding)): func(m string) (interface{}, bool) { return base64.StdEncoding, false },
+	reflect.TypeOf(new(base32.Encoding)): func(m string) (interface{}, bool) { return base32.StdEncoding, false },
+	reflect.TypeOf(new(log.Logger)):      func(m string) (interface{}, bool) { return log.New(new(bytes.Buffer), m, 0), true },
+	reflect.TypeOf(new(scanner.Scanner)): func(m string) (interface{}, bool) { return new(scanner.Scanner).Init(strings.NewReader(m)), true },
+	reflect.TypeOf(new(tabwriter.Writer)): func(m string) (interface{}, bool) {
+		return tabwriter.NewWriter(new(bytes.Buffer), 0, 8, 1, ' ', 0), false
+	},
+	reflect.TypeOf(new(gzip.Writer)): func(m string) (interface{}, bool) { return gzip.NewWriter(new(bytes.Buffer)), false },
+	reflect.TypeOf(new(gzip.Reader)): func(m string) (interface{}, bool) {
+		var b bytes.Buffer
+		w := gzip.NewWriter(&b)
+		w.Write([]byte(m))
+		w.Close()
+		r, _ := gzip.NewReader(&b)
+		return r, true
+	},
+	reflect.TypeOf(new(sync.Map)): func(m string) (interface{}, bool) {
+		s := new(sync.Map)
+		s.Store(m, m)
+		return s, true
+	},
+	reflect.TypeOf(new(sync.Pool)): func(m string) (interface{}, bool) { return new(sync.Pool), false },
+	reflect.TypeOf(sha256.New):     func(m string) (interface{}, bool) { return sha256.New, false },
+	reflect.TypeOf(bufio.SplitFunc(nil)): func(m string) (interface{}, bool) {
+		return bufio.SplitFunc(bufio.ScanWords), false
+	},
+	reflect.TypeOf(unicode.IsSpace): func(m string) (interface{}, bool) { return unicode.IsSpace, false },
+	reflect.TypeOf(unicode.ToUpper): func(m string) (interface{}, bool) { return unicode.ToUpper, false },
+	reflect.TypeOf(new(io.PipeReader)): func(m string) (interface{}, bool) {
+		r, _ := io.Pipe()
+		return r, false
+	},
+	reflect.TypeOf(new(io.PipeWriter)): func(m string) (interface{}, bool) {
+		_, w := io.Pipe()
+		return w, false
+	},
+}
+
+// implementations construct values of interface types from a marker.
+// The first one that implements an interface type is used.
+var implementations = []func(m string) (interface{}, bool){
+	func(m string) (interface{}, bool) { return m, true },
+	func(m string) (interface{}, bool) { return errors.New(m), true },
+	func(m string) (interface{}, bool) { return bytes.NewBufferString(m), true },
+	func(m string) (interface{}, bool) { return strings.NewReader(m), true },
+	func(m string) (interface{}, bool) { return stringer(m), true },
+	func(m string) (interface{}, bool) { return context.Background(), false },
+	func(m string) (interface{}, bool) {
+		b, err := aes.NewCipher([]byte(m))
+		if err != nil {
+			panic(err)
+		}
+		return b, true
+	},
+	func(m string) (interface{}, bool) { return sha256.New(), false },
+}
+
+// A renderer writes a deterministic description of a value, including the values
+// that it points to and its unexported fields, but not addresses.
+type renderer struct {
+	b    strings.Builder
+	seen map[uintptr]bool
+}
+
+const (
+	maxDepth  = 12
+	maxLength = 1 << 20
+)
+
+func (r *renderer) render(v reflect.Value, depth int) {
+	if depth > maxDepth || r.b.Len() > maxLength {
+		r.b.WriteString("...")
+		return
+	}
+	switch v.Kind() {
+	case reflect.Invalid:
+		r.b.WriteString("<nil>")
+	case reflect.Bool:
+		r.b.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r.b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		r.b.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		r.b.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.Complex64, reflect.Complex128:
+		fmt.Fprint(&r.b, v.Complex())
+	case reflect.String:
+		r.b.WriteString(strconv.Quote(v.String()))
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			r.b.WriteString("<nil>")
+			return
+		}
+		// Bytes are rendered as a string, in which markers can be found.
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			for i := range b {
+				b[i] = byte(v.Index(i).Uint())
+			}
+			r.b.WriteString(strconv.Quote(string(b)))
+			return
+		}
+		r.b.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			r.render(v.Index(i), depth+1)
+			r.b.WriteString(" ")
+		}
+		r.b.WriteString("]")
+	case reflect.Map:
+		if v.IsNil() {
+			r.b.WriteString("<nil>")
+			return
+		}
+		var entries []string
+		for _, k := range v.MapKeys() {
+			e := &renderer{seen: r.seen}
+			e.render(k, depth+1)
+			e.b.WriteString(":")
+			e.render(v.MapIndex(k), depth+1)
+			entries = append(entries, e.b.String())
+		}
+		sort.Strings(entries)
+		r.b.WriteString("map[" + strings.Join(entries, " ") + "]")
+	case reflect.Struct:
+		r.b.WriteString("{")
+		for i := 0; i < v.NumField(); i++ {
+			r.render(v.Field(i), depth+1)
+			r.b.WriteString(" ")
+		}
+		r.b.WriteString("}")
+	case reflect.Ptr:
+		if v.IsNil() {
+			r.b.WriteString("<nil>")
+			return
+		}
+		if r.seen[v.Pointer()] {
+			r.b.WriteString("<cycle>")
+			return
+		}
+		r.seen[v.Pointer()] = true
+		r.b.WriteString("&")
+		r.render(v.Elem(), depth+1)
+		delete(r.seen, v.Pointer())
+	case reflect.Interface:
+		if v.IsNil() {
+			r.b.WriteString("<nil>")
+			return
+		}
+		r.render(v.Elem(), depth+1)
+	default:
+		// Functions, channels and unsafe pointers are only described by their kind.
+		r.b.WriteString("<" + v.Kind().String() + ">")
+	}
+}
+
+// Main observes functions, keyed by their summary keys, and writes
+// the observations to standard output as JSON. It is called by the program
+// generated by Program, which has access to the functions.
+func Main(funcs map[string]interface{}) {
+	var keys []string
+	for k := range funcs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var observations []Observation
+	for _, k := range keys {
+		observations = append(observations, Observe(k, funcs[k]))
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(observations); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// Program returns the source of a program that observes the functions
+// whose summaries are valid, by calling Main. The program must be built in
+// this module, with the toolchain whose standard library the summaries were
+// resolved against. Methods of unexported types cannot be referred to,
+// and are left out.
+func Program(funcs []Function) ([]byte, error) {
+	aliases := map[string]string{}
+	var entries []string
+	for _, f := range funcs {
+		if f.Object == nil || f.Problem != "" {
+			continue
+		}
+		path, recv, name, _ := parseKey(f.Key)
+		if recv != "" && !ast.IsExported(strings.TrimPrefix(recv, "*")) {
+			continue
+		}
+		alias, ok := aliases[path]
+		if !ok {
+			alias = fmt.Sprintf("p%d", len(aliases))
+			aliases[path] = alias
+		}
+		var expr string
+		switch {
+		case recv == "":
+			expr = alias + "." + name
+		case strings.HasPrefix(recv, "*"):
+			expr = fmt.Sprintf("(*%s.%s).%s", alias, recv[1:], name)
+		default:
+			expr = fmt.Sprintf("%s.%s.%s", alias, recv, name)
+		}
+		entries = append(entries, fmt.Sprintf("\t\t%q: %s,\n", f.Key, expr))
+	}
+
+	var paths []string
+	for p := range aliases {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	b.WriteString("// Code generated by verify.Program. DO NOT EDIT.\n\npackage main\n\nimport (\n")
+	b.WriteString("\t\"github.com/google/go-flow-levee/internal/pkg/propagation/summary/verify\"\n")
+	for _, p := range paths {
+		fmt.Fprintf(&b, "\t%s %q\n", aliases[p], p)
+	}
+	b.WriteString(")\n\nfunc main() {\n\tverify.Main(map[string]interface{}{\n")
+	for _, e := range entries {
+		b.WriteString(e)
+	}
+	b.WriteString("\t})\n}\n")
+	return format.Source([]byte(b.String()))
+}
@@ -0,0 +1,170 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package verify checks the summaries of the summary package against
+// the standard library of the current Go toolchain.
+//
+// Summaries are checked statically, by resolving their keys to functions
+// and methods with go/types, and dynamically, by calling the functions with
+// distinctive marker values and observing which arguments and results change
+// when the marker of an input changes.
+package verify
+
+import (
+	"fmt"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/google/go-flow-levee/internal/pkg/propagation/summary"
+)
+
+// A Function is a standard library function or method that has a summary.
+type Function struct {
+	Key     string
+	Summary summary.Summary
+	// Object is the function or method that the key resolves to, if any.
+	Object *types.Func
+	// Problem explains why the key does not resolve, or why the summary
+	// does not fit the function's signature. It is empty if the summary is valid.
+	Problem string
+}
+
+// Resolve resolves the keys of the summaries of standard library functions,
+// e.g. "(*strings.Replacer).Replace", and checks that their summaries fit
+// the signatures of these functions. The result is ordered by key.
+func Resolve(summaries map[string]summary.Summary) []Function {
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil)
+	var funcs []Function
+	for key, s := range summaries {
+		path, _, _, ok := parseKey(key)
+		if ok && !isStdlib(path) {
+			continue
+		}
+		f := Function{Key: key, Summary: s}
+		f.Object, f.Problem = resolve(imp, key)
+		if f.Problem == "" {
+			f.Problem = checkPositions(f.Object, s)
+		}
+		funcs = append(funcs, f)
+	}
+	sort.Slice(funcs, func(i, j int) bool { return funcs[i].Key < funcs[j].Key })
+	return funcs
+}
+
+// isStdlib determines whether a package belongs to the standard library,
+// i.e. whether the first element of its path has no dot.
+func isStdlib(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
+
+// parseKey splits a key into a package path, a receiver and a name,
+// e.g. "(*strings.Replacer).Replace" into "strings", "*Replacer" and "Replace".
+func parseKey(key string) (path, recv, name string, ok bool) {
+	dot := strings.LastIndex(key, ".")
+	if dot < 0 {
+		return "", "", "", false
+	}
+	qualified, name := key[:dot], key[dot+1:]
+	if !strings.HasPrefix(qualified, "(") {
+		return qualified, "", name, true
+	}
+	if !strings.HasSuffix(qualified, ")") {
+		return "", "", "", false
+	}
+	qualified = qualified[1 : len(qualified)-1]
+	ptr := strings.HasPrefix(qualified, "*")
+	qualified = strings.TrimPrefix(qualified, "*")
+	dot = strings.LastIndex(qualified, ".")
+	if dot < 0 {
+		return "", "", "", false
+	}
+	path, recv = qualified[:dot], qualified[dot+1:]
+	if ptr {
+		recv = "*" + recv
+	}
+	return path, recv, name, true
+}
+
+func resolve(imp types.Importer, key string) (*types.Func, string) {
+	path, recv, name, ok := parseKey(key)
+	if !ok {
+		return nil, "the key is not a qualified function or method name"
+	}
+	pkg, err := imp.Import(path)
+	if err != nil {
+		return nil, fmt.Sprintf("package %s cannot be imported: %v", path, err)
+	}
+	if recv == "" {
+		fn, ok := pkg.Scope().Lookup(name).(*types.Func)
+		if !ok {
+			return nil, fmt.Sprintf("package %s has no function %s", path, name)
+		}
+		return fn, ""
+	}
+
+	tn, ok := pkg.Scope().Lookup(strings.TrimPrefix(recv, "*")).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Sprintf("package %s has no type %s", path, strings.TrimPrefix(recv, "*"))
+	}
+	if tn.IsAlias() {
+		return nil, fmt.Sprintf("%s.%s is a type alias, so its methods are keyed by the type that it denotes", path, tn.Name())
+	}
+	named, ok := tn.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Sprintf("%s.%s is not a named type", path, tn.Name())
+	}
+	for i := 0; i < named.NumMethods(); i++ {
+		m := named.Method(i)
+		if m.Name() != name {
+			continue
+		}
+		_, isPtr := m.Type().(*types.Signature).Recv().Type().(*types.Pointer)
+		if isPtr != strings.HasPrefix(recv, "*") {
+			return nil, fmt.Sprintf("the receiver of %s is %s", name, types.TypeString(m.Type().(*types.Signature).Recv().Type(), types.RelativeTo(pkg)))
+		}
+		return m, ""
+	}
+	return nil, fmt.Sprintf("type %s.%s has no method %s", path, tn.Name(), name)
+}
+
+// checkPositions checks that the positions of a summary are those of
+// the parameters and results of a function. The receiver of a method
+// counts as its first parameter.
+func checkPositions(fn *types.Func, s summary.Summary) string {
+	sig := fn.Type().(*types.Signature)
+	params := sig.Params().Len()
+	if sig.Recv() != nil {
+		params++
+	}
+	if s.IfTainted == 0 {
+		return "IfTainted is empty"
+	}
+	if s.IfTainted>>uint(params) != 0 {
+		return fmt.Sprintf("IfTainted is %#b, but there are only %d parameters", s.IfTainted, params)
+	}
+	for _, a := range s.TaintedArgs {
+		if a < 0 || a >= params {
+			return fmt.Sprintf("TaintedArgs contains %d, but there are only %d parameters", a, params)
+		}
+	}
+	for _, r := range s.TaintedRets {
+		if r < 0 || r >= sig.Results().Len() {
+			return fmt.Sprintf("TaintedRets contains %d, but there are only %d results", r, sig.Results().Len())
+		}
+	}
+	return ""
+}
@@ -0,0 +1,332 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-flow-levee/internal/pkg/propagation/summary"
+)
+
+// acceptedMisses lists the flows that summaries leave out on purpose.
+// They are described as by Compare.
+var acceptedMisses = map[string][]string{
+	// The names of templates only appear in the errors returned when they cannot be executed.
+	"(*text/template.Template).Execute":         {"argument 0 taints result 0"},
+	"(*text/template.Template).ExecuteTemplate": {"argument 0 taints result 0", "argument 2 taints result 0"},
+	"(*html/template.Template).Execute":         {"argument 0 taints result 0"},
+	"(*html/template.Template).ExecuteTemplate": {"argument 2 taints result 0"},
+	// The keys of attributes are not considered to carry data. Sensitive keys are configured via SensitiveKeyRE.
+	"log/slog.Any":      {"argument 0 taints result 0"},
+	"log/slog.Bool":     {"argument 0 taints result 0"},
+	"log/slog.Duration": {"argument 0 taints result 0"},
+	"log/slog.Float64":  {"argument 0 taints result 0"},
+	"log/slog.Group":    {"argument 0 taints result 0"},
+	"log/slog.Int":      {"argument 0 taints result 0"},
+	"log/slog.Int64":    {"argument 0 taints result 0"},
+	"log/slog.String":   {"argument 0 taints result 0"},
+	"log/slog.Time":     {"argument 0 taints result 0"},
+	"log/slog.Uint64":   {"argument 0 taints result 0"},
+}
+
+func TestParseKey(t *testing.T) {
+	cases := []struct {
+		key, path, recv, name string
+		ok                    bool
+	}{
+		{"fmt.Sprintf", "fmt", "", "Sprintf", true},
+		{"encoding/base64.NewEncoder", "encoding/base64", "", "NewEncoder", true},
+		{"(*strings.Replacer).Replace", "strings", "*Replacer", "Replace", true},
+		{"(net/url.Values).Get", "net/url", "Values", "Get", true},
+		{"(*go.uber.org/zap.Logger).With", "go.uber.org/zap", "*Logger", "With", true},
+		{"Sprintf", "", "", "", false},
+		{"(*strings.Replacer.Replace", "", "", "", false},
+	}
+	for _, tc := range cases {
+		path, recv, name, ok := parseKey(tc.key)
+		if path != tc.path || recv != tc.recv || name != tc.name || ok != tc.ok {
+			t.Errorf("parseKey(%q) = %q, %q, %q, %v, want %q, %q, %q, %v", tc.key, path, recv, name, ok, tc.path, tc.recv, tc.name, tc.ok)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	funcs := Resolve(map[string]summary.Summary{
+		"strings.ToUpper":                      {IfTainted: 1, TaintedRets: []int{0}},
+		"(*strings.Replacer).Replace":          {IfTainted: 0b11, TaintedRets: []int{0}},
+		"strings.NoSuchFunction":               {IfTainted: 1, TaintedRets: []int{0}},
+		"(strings.Replacer).Replace":           {IfTainted: 1, TaintedRets: []int{0}},
+		"io.WriteString":                       {IfTainted: 0b100, TaintedArgs: []int{0}},
+		"strings.ToLower":                      {IfTainted: 1, TaintedRets: []int{1}},
+		"(*go.uber.org/zap.Logger).With":       {IfTainted: 1, TaintedRets: []int{0}},
+		"github.com/sirupsen/logrus.WithField": {IfTainted: 1, TaintedRets: []int{0}},
+	})
+
+	got := map[string]string{}
+	for _, f := range funcs {
+		got[f.Key] = f.Problem
+	}
+	want := map[string]string{
+		"strings.ToUpper":             "",
+		"(*strings.Replacer).Replace": "",
+		"strings.NoSuchFunction":      "package strings has no function NoSuchFunction",
+		"(strings.Replacer).Replace":  "the receiver of Replace is *Replacer",
+		"io.WriteString":              "IfTainted is 0b100, but there are only 2 parameters",
+		"strings.ToLower":             "TaintedRets contains 1, but there are only 1 results",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Resolve() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestFuncSummaryKeysResolve(t *testing.T) {
+	for _, f := range Resolve(summary.FuncSummaries) {
+		if f.Problem == "" {
+			continue
+		}
+		if path, _, _, ok := parseKey(f.Key); ok && missingFromGOROOT(path) {
+			t.Logf("%s: %s (not provided by this toolchain)", f.Key, f.Problem)
+			continue
+		}
+		t.Errorf("%s: %s", f.Key, f.Problem)
+	}
+}
+
+// missingFromGOROOT determines whether a package of the standard library
+// is not provided by the toolchain in use, e.g. because it was added in a later release.
+func missingFromGOROOT(path string) bool {
+	if !isStdlib(path) {
+		return false
+	}
+	_, err := build.Import(path, "", build.FindOnly)
+	return err != nil
+}
+
+func TestExtendedFuncSummaryPathsResolve(t *testing.T) {
+	// The positions of the paths are checked by converting them into a Summary.
+	summs := make(map[string]summary.Summary)
+	for key, ext := range summary.ExtendedFuncSummaries {
+		var s summary.Summary
+		for _, p := range ext.IfTainted {
+			s.IfTainted |= 1 << uint(p.Index)
+		}
+		for _, p := range ext.TaintedArgs {
+			s.TaintedArgs = append(s.TaintedArgs, p.Index)
+		}
+		for _, p := range ext.TaintedRets {
+			s.TaintedRets = append(s.TaintedRets, p.Index)
+		}
+		summs[key] = s
+	}
+	for _, f := range Resolve(summs) {
+		if f.Problem != "" {
+			t.Errorf("%s: %s", f.Key, f.Problem)
+			continue
+		}
+		ext := summary.ExtendedFuncSummaries[f.Key]
+		sig := f.Object.Type().(*types.Signature)
+		var params []types.Type
+		if sig.Recv() != nil {
+			params = append(params, sig.Recv().Type())
+		}
+		for i := 0; i < sig.Params().Len(); i++ {
+			params = append(params, sig.Params().At(i).Type())
+		}
+		for _, p := range append(ext.IfTainted, ext.TaintedArgs...) {
+			if problem := checkAccesses(params[p.Index], p.Accesses); problem != "" {
+				t.Errorf("%s: %s: %s", f.Key, p, problem)
+			}
+		}
+		for _, p := range ext.TaintedRets {
+			if problem := checkAccesses(sig.Results().At(p.Index).Type(), p.Accesses); problem != "" {
+				t.Errorf("%s: %s: %s", f.Key, p, problem)
+			}
+		}
+	}
+}
+
+// checkAccesses returns a description of the first access that does not
+// match the type it is applied to, or "" if all of them match.
+func checkAccesses(t types.Type, accesses []summary.Access) string {
+	for _, a := range accesses {
+		if a.Field != "" {
+			f := summary.SelectField(t, a.Field)
+			if f == nil {
+				return fmt.Sprintf("%s has no field %s", t, a.Field)
+			}
+			t = f.Type()
+			continue
+		}
+		s, ok := t.Underlying().(*types.Slice)
+		if !ok {
+			return fmt.Sprintf("%s is not a slice", t)
+		}
+		t = s.Elem()
+	}
+	return ""
+}
+
+func isAccepted(key, flow string) bool {
+	for _, f := range acceptedMisses[key] {
+		if f == flow {
+			return true
+		}
+	}
+	return false
+}
+
+func copyString(dst *string, src string) { *dst = src }
+
+func hashString(s string) []byte {
+	sum := sha256.Sum256([]byte(s))
+	return sum[:]
+}
+
+func lookup(m map[string]string, key string) string { return m[key] }
+
+func length(s string) int { return len(s) }
+
+func mustBeEmpty(s string) string {
+	if s != "" {
+		panic("not empty")
+	}
+	return s
+}
+
+func TestObserve(t *testing.T) {
+	cases := []struct {
+		desc string
+		fn   interface{}
+		want Observation
+	}{
+		{
+			desc: "copying into an argument",
+			fn:   copyString,
+			want: Observation{Varied: []int{0, 1}, Flows: []Flow{{From: 1, Args: []int{0}, CopiedArgs: []int{0}}}},
+		},
+		{
+			desc: "hashing",
+			fn:   hashString,
+			want: Observation{Varied: []int{0}, Flows: []Flow{{From: 0, Rets: []int{0}}}},
+		},
+		{
+			desc: "looking up a key",
+			fn:   lookup,
+			want: Observation{Varied: []int{0, 1}, Flows: []Flow{{From: 0, Rets: []int{0}, CopiedRets: []int{0}}, {From: 1, Rets: []int{0}}}},
+		},
+		{
+			desc: "integer results are not observed",
+			fn:   length,
+			want: Observation{Varied: []int{0}},
+		},
+		{
+			desc: "a panicking call is untested",
+			fn:   mustBeEmpty,
+			want: Observation{Untested: "the call panics: not empty"},
+		},
+		{
+			desc: "a parameter whose value cannot be constructed",
+			fn:   func(c chan string) string { return <-c },
+			want: Observation{Untested: "no value can be constructed for type chan string"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := Observe("", tc.fn)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Observe() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	obs := Observation{
+		Varied: []int{0, 1},
+		Flows: []Flow{
+			{From: 0, Rets: []int{0}, CopiedRets: []int{0}},
+			{From: 1, Rets: []int{0, 1}, CopiedRets: []int{1}},
+		},
+	}
+	missed, unobserved := Compare(summary.Summary{IfTainted: 0b11, TaintedArgs: []int{0}, TaintedRets: []int{0}}, obs)
+	if diff := cmp.Diff([]string{"argument 1 taints result 1"}, missed); diff != "" {
+		t.Errorf("missed flows diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"argument 0 is not tainted"}, unobserved); diff != "" {
+		t.Errorf("unobserved flows diff (-want +got):\n%s", diff)
+	}
+}
+
+// TestFuncSummariesAreObserved builds and runs a program that observes the
+// summarized functions of the standard library, and compares the observations
+// with their summaries. Only missed flows are errors: flows that are not observed
+// and functions that cannot be called are logged.
+func TestFuncSummariesAreObserved(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building the observation program is slow")
+	}
+	src, err := Program(Resolve(summary.FuncSummaries))
+	if err != nil {
+		t.Fatalf("generating the observation program: %v", err)
+	}
+	// The program is built in this module, so that it can import this package.
+	dir, err := ioutil.TempDir(".", "_program")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "main.go"), src, 0644); err != nil {
+		t.Fatal(err)
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", "run", "./"+filepath.Base(dir))
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("running the observation program: %v\n%s", err, stderr.String())
+	}
+	var observations []Observation
+	if err := json.Unmarshal(out, &observations); err != nil {
+		t.Fatalf("reading observations: %v", err)
+	}
+
+	for _, obs := range observations {
+		if obs.Untested != "" {
+			t.Logf("%s: untested: %s", obs.Key, obs.Untested)
+			continue
+		}
+		missed, unobserved := Compare(summary.FuncSummaries[obs.Key], obs)
+		for _, m := range missed {
+			if !isAccepted(obs.Key, m) {
+				t.Errorf("%s: the summary misses a flow: %s", obs.Key, m)
+			}
+		}
+		for _, u := range unobserved {
+			t.Logf("%s: unobserved: %s", obs.Key, u)
+		}
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sinkdiscovery defines an analyzer that suggests Sinks, by finding
+// functions that write their parameters to an output, such as os.Stdout,
+// an io.Writer, the network or a file, either directly or through other
+// functions. Suggestions are ranked by the number of calls to each function.
+package sinkdiscovery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
+	"github.com/google/go-flow-levee/internal/pkg/propagation"
+	"github.com/google/go-flow-levee/internal/pkg/utils"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "sinkdiscovery",
+	Doc: `suggests Sinks by finding functions that write their parameters to an output
+
+A function is reported if one of its parameters reaches a write to os.Stdout,
+os.Stderr, an io.Writer, the network or a file, or a call to a configured Sink,
+possibly through calls to other such functions. Parameters are followed using
+the same taint propagation as go-flow-levee. In main packages, the functions
+found in the program are reported as a YAML snippet of Sinks, ranked by the
+number of calls to each function.`,
+	Flags:     config.FlagSet,
+	Run:       run,
+	Requires:  []*analysis.Analyzer{buildssa.Analyzer, fieldtags.Analyzer},
+	FactTypes: []analysis.Fact{new(writesParameters), new(candidateSinks)},
+}
+
+// writesParameters is an object fact that records which parameters of a function,
+// not counting the receiver, are written to which outputs.
+type writesParameters struct {
+	Params  []int
+	Outputs []string
+}
+
+func (w *writesParameters) AFact() {}
+
+func (w *writesParameters) String() string {
+	return fmt.Sprintf("writes parameters %v to %s", w.Params, strings.Join(w.Outputs, ", "))
+}
+
+// A Candidate is a function that writes some of its parameters to an output.
+type Candidate struct {
+	Package, Receiver, Method string
+	// Params are the names of the parameters that are written.
+	Params []string
+	// Argument is the position of the only parameter that is written, not counting the receiver,
+	// or -1 if other parameters are also written, or if the function has a single parameter.
+	Argument int
+	Outputs  []string
+	// CallSites counts the calls to the function, by calling package.
+	CallSites []CallSites
+}
+
+// CallSites is the number of calls made to a function by a package.
+type CallSites struct {
+	Package string
+	Count   int
+}
+
+// TotalCallSites counts the calls to the candidate's function in all packages.
+func (c Candidate) TotalCallSites() int {
+	total := 0
+	for _, cs := range c.CallSites {
+		total += cs.Count
+	}
+	return total
+}
+
+// addCallSites records calls made by a package. Since a package may be
+// imported through several paths, calls are only recorded once per package.
+func (c *Candidate) addCallSites(pkg string, count int) {
+	for i, cs := range c.CallSites {
+		if cs.Package == pkg {
+			c.CallSites[i].Count = count
+			return
+		}
+	}
+	c.CallSites = append(c.CallSites, CallSites{Package: pkg, Count: count})
+	sort.Slice(c.CallSites, func(i, j int) bool { return c.CallSites[i].Package < c.CallSites[j].Package })
+}
+
+// candidateSinks is a package fact that accumulates the candidates found in a package
+// and in its dependencies, along with the calls made to them.
+// Candidates are sorted by function, so that the fact is encoded deterministically.
+type candidateSinks struct {
+	Candidates []Candidate
+}
+
+func (cs *candidateSinks) AFact() {}
+
+func (cs *candidateSinks) String() string {
+	return fmt.Sprintf("candidate sinks: %d", len(cs.Candidates))
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	// The writes performed by the standard library are known, see outputOf.
+	if isStandardPackage(pass.Pkg.Path()) {
+		return nil, nil
+	}
+	conf, err := config.ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+	ssaInput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	taggedFields := pass.ResultOf[fieldtags.Analyzer].(fieldtags.ResultType)
+	excludedFiles := conf.ExcludedFiles(pass.Fset, pass.Files)
+
+	found := findWriters(conf, taggedFields, ssaInput.SrcFuncs, func(callee *ssa.Function) *writesParameters {
+		var w writesParameters
+		if callee.Object() != nil && callee.Object().Pkg() != pass.Pkg && pass.ImportObjectFact(callee.Object(), &w) {
+			return &w
+		}
+		return nil
+	})
+
+	candidates := importCandidates(pass)
+	for _, fn := range ssaInput.SrcFuncs {
+		w, ok := found[fn]
+		if !ok || fn.Object() == nil {
+			continue
+		}
+		pass.ExportObjectFact(fn.Object(), w)
+		if excludedFiles[pass.Fset.File(fn.Pos())] || conf.IsSink(utils.DecomposeFunction(fn)) {
+			continue
+		}
+		c := newCandidate(fn, w)
+		candidates[funcKey(fn)] = c
+		pass.Reportf(fn.Pos(), "candidate sink: %s writes %s to %s", fn.Name(), strings.Join(c.Params, ", "), strings.Join(c.Outputs, ", "))
+	}
+
+	countCallSites(pass.Pkg.Path(), ssaInput.SrcFuncs, candidates)
+	if len(candidates) > 0 {
+		pass.ExportPackageFact(newCandidateSinks(candidates))
+	}
+	if pass.Pkg.Name() == "main" && len(candidates) > 0 && len(pass.Files) > 0 {
+		pass.Reportf(pass.Files[0].Package, "candidate sinks, by number of call sites:\n%s", Suggest(candidates))
+	}
+	return nil, nil
+}
+
+// isStandardPackage determines whether a package belongs to the standard library,
+// i.e. whether the first element of its path does not contain a dot.
+func isStandardPackage(path string) bool {
+	first := strings.SplitN(path, "/", 2)[0]
+	return !strings.Contains(first, ".")
+}
+
+// findWriters finds the functions that write their parameters to an output.
+// Since functions may write their parameters by calling each other,
+// they are analyzed until no new writes are found.
+func findWriters(conf *config.Config, taggedFields fieldtags.ResultType, funcs []*ssa.Function, imported func(*ssa.Function) *writesParameters) map[*ssa.Function]*writesParameters {
+	found := map[*ssa.Function]*writesParameters{}
+	lookup := func(callee *ssa.Function) *writesParameters {
+		if w, ok := found[callee]; ok {
+			return w
+		}
+		return imported(callee)
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, fn := range funcs {
+			w := writtenParameters(conf, taggedFields, fn, lookup)
+			if w == nil {
+				continue
+			}
+			if prev, ok := found[fn]; !ok || prev.String() != w.String() {
+				found[fn] = w
+				changed = true
+			}
+		}
+	}
+	return found
+}
+
+// writtenParameters determines which parameters of a function are written to an output,
+// or returns nil if none of them are.
+func writtenParameters(conf *config.Config, taggedFields fieldtags.ResultType, fn *ssa.Function, lookup func(*ssa.Function) *writesParameters) *writesParameters {
+	params := fn.Params
+	if fn.Signature.Recv() != nil && len(params) > 0 {
+		params = params[1:]
+	}
+	w := &writesParameters{}
+	outputs := map[string]bool{}
+	for i, p := range params {
+		prop := propagation.Taint(p, conf, taggedFields, nil)
+		written := false
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				call, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				outs, args := outputOf(conf, call, lookup)
+				for _, a := range args {
+					if prop.IsTaintedArg(call, a) {
+						written = true
+						for _, o := range outs {
+							outputs[o] = true
+						}
+						break
+					}
+				}
+			}
+		}
+		if written {
+			w.Params = append(w.Params, i)
+		}
+	}
+	if len(w.Params) == 0 {
+		return nil
+	}
+	for o := range outputs {
+		w.Outputs = append(w.Outputs, o)
+	}
+	sort.Strings(w.Outputs)
+	return w
+}
+
+// importCandidates returns the candidates found in the dependencies of a package, keyed by function.
+func importCandidates(pass *analysis.Pass) map[string]*Candidate {
+	candidates := map[string]*Candidate{}
+	for _, imp := range pass.Pkg.Imports() {
+		var fact candidateSinks
+		if !pass.ImportPackageFact(imp, &fact) {
+			continue
+		}
+		for _, c := range fact.Candidates {
+			k := candidateKey(c.Package, c.Receiver, c.Method)
+			merged, ok := candidates[k]
+			if !ok {
+				cp := c
+				cp.CallSites = nil
+				merged = &cp
+				candidates[k] = merged
+			}
+			for _, cs := range c.CallSites {
+				merged.addCallSites(cs.Package, cs.Count)
+			}
+		}
+	}
+	return candidates
+}
+
+func newCandidateSinks(candidates map[string]*Candidate) *candidateSinks {
+	var keys []string
+	for k := range candidates {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fact := &candidateSinks{}
+	for _, k := range keys {
+		fact.Candidates = append(fact.Candidates, *candidates[k])
+	}
+	return fact
+}
+
+// countCallSites counts the calls made by the functions of a package to the candidates.
+func countCallSites(pkgPath string, funcs []*ssa.Function, candidates map[string]*Candidate) {
+	counts := map[string]int{}
+	for _, fn := range funcs {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				call, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				callee := call.Common().StaticCallee()
+				if callee == nil {
+					continue
+				}
+				if _, ok := candidates[funcKey(callee)]; ok {
+					counts[funcKey(callee)]++
+				}
+			}
+		}
+	}
+	for k, n := range counts {
+		candidates[k].addCallSites(pkgPath, n)
+	}
+}
+
+func newCandidate(fn *ssa.Function, w *writesParameters) *Candidate {
+	path, recv, name := utils.DecomposeFunction(fn)
+	c := &Candidate{
+		Package:  path,
+		Receiver: recv,
+		Method:   name,
+		Argument: -1,
+		Outputs:  w.Outputs,
+	}
+	params := fn.Signature.Params()
+	for _, i := range w.Params {
+		c.Params = append(c.Params, params.At(i).Name())
+	}
+	if len(w.Params) == 1 && params.Len() > 1 {
+		c.Argument = w.Params[0]
+	}
+	return c
+}
+
+// funcKey identifies a function across packages.
+func funcKey(fn *ssa.Function) string {
+	return candidateKey(utils.DecomposeFunction(fn))
+}
+
+func candidateKey(path, recv, name string) string {
+	return fmt.Sprintf("%s.%s.%s", path, recv, name)
+}
+
+// Suggest returns a YAML snippet of Sinks matching the candidates, ordered by decreasing
+// number of call sites. Each Sink is followed by a comment stating its number of call sites,
+// and which parameters it writes to which outputs.
+func Suggest(candidates map[string]*Candidate) string {
+	var keys []string
+	for k := range candidates {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ci, cj := candidates[keys[i]].TotalCallSites(), candidates[keys[j]].TotalCallSites()
+		if ci != cj {
+			return ci > cj
+		}
+		return keys[i] < keys[j]
+	})
+
+	var b strings.Builder
+	b.WriteString("Sinks:\n")
+	for _, k := range keys {
+		c := candidates[k]
+		fmt.Fprintf(&b, "- Package: %q\n", c.Package)
+		fmt.Fprintf(&b, "  Receiver: %q\n", c.Receiver)
+		fmt.Fprintf(&b, "  Method: %q\n", c.Method)
+		if c.Argument >= 0 {
+			fmt.Fprintf(&b, "  Argument: %d\n", c.Argument)
+		}
+		fmt.Fprintf(&b, "  # %s, writes %s to %s\n", pluralize(c.TotalCallSites(), "call site"), strings.Join(c.Params, ", "), strings.Join(c.Outputs, ", "))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkdiscovery
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestSinkDiscovery(t *testing.T) {
+	testdata := analysistest.TestData()
+	if err := config.FlagSet.Set("config", filepath.Join(testdata, "test-config.yaml")); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, testdata, Analyzer, "./...")
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := map[string]*Candidate{
+		"b": {Package: "example.com/b", Method: "Print", Params: []string{"msg"}, Argument: -1, Outputs: []string{stdout}},
+		"a": {Package: "example.com/a", Method: "Save", Params: []string{"data"}, Argument: 1, Outputs: []string{file}},
+		"c": {
+			Package:   "example.com/c",
+			Receiver:  "*Logger",
+			Method:    "Info",
+			Params:    []string{"msg"},
+			Argument:  -1,
+			Outputs:   []string{stderr},
+			CallSites: []CallSites{{Package: "example.com/app", Count: 2}, {Package: "example.com/b", Count: 1}},
+		},
+	}
+
+	got := Suggest(candidates)
+	want := `Sinks:
+- Package: "example.com/c"
+  Receiver: "*Logger"
+  Method: "Info"
+  # 3 call sites, writes msg to os.Stderr
+- Package: "example.com/a"
+  Receiver: ""
+  Method: "Save"
+  Argument: 1
+  # 0 call sites, writes data to a file
+- Package: "example.com/b"
+  Receiver: ""
+  Method: "Print"
+  # 0 call sites, writes msg to os.Stdout`
+	if got != want {
+		t.Errorf("Suggest() = \n%s\nwant:\n%s", got, want)
+	}
+
+	conf, err := config.Parse([]byte(got))
+	if err != nil {
+		t.Fatalf("suggestions are not a valid configuration: %v", err)
+	}
+	if arg, ok := conf.SinkArgument("example.com/a", "", "Save"); !ok || arg != 1 {
+		t.Errorf("SinkArgument(Save) = %d, %v, want 1, true", arg, ok)
+	}
+	if !conf.IsSink("example.com/c", "*Logger", "Info") {
+		t.Error("(*Logger).Info should be a sink")
+	}
+}
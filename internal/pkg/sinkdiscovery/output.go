@@ -0,0 +1,145 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sinkdiscovery
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/utils"
+	"golang.org/x/tools/go/ssa"
+)
+
+// Outputs that values can be written to.
+const (
+	stdout         = "os.Stdout"
+	stderr         = "os.Stderr"
+	file           = "a file"
+	network        = "the network"
+	writer         = "an io.Writer"
+	configuredSink = "a configured sink"
+)
+
+// outputOf determines whether a call writes some of its arguments to an output,
+// e.g. fmt.Println or (io.Writer).Write. If so, it returns a description of the output
+// and the positions of the arguments that are written, not counting the receiver.
+// Calls to functions that have been found to write their parameters are described
+// by the facts returned by lookup.
+func outputOf(conf *config.Config, call ssa.CallInstruction, lookup func(*ssa.Function) *writesParameters) ([]string, []int) {
+	cc := call.Common()
+	if cc.IsInvoke() {
+		switch cc.Method.Name() {
+		case "Write", "WriteString":
+			return []string{writerOutput(cc.Value)}, []int{0}
+		}
+		return nil, nil
+	}
+	if b, ok := cc.Value.(*ssa.Builtin); ok {
+		if b.Name() == "print" || b.Name() == "println" {
+			return []string{stderr}, allArgs(cc)
+		}
+		return nil, nil
+	}
+
+	callee := cc.StaticCallee()
+	if callee == nil {
+		return nil, nil
+	}
+	path, recv, name := utils.DecomposeFunction(callee)
+	switch {
+	case path == "fmt" && recv == "" && strings.HasPrefix(name, "Print"):
+		return []string{stdout}, allArgs(cc)
+	case path == "fmt" && recv == "" && strings.HasPrefix(name, "Fprint"):
+		return []string{writerOutput(cc.Args[0])}, allArgs(cc)[1:]
+	case path == "log" && recv == "" && isLogFunction(name):
+		return []string{stderr}, allArgs(cc)
+	case path == "log" && recv == "*Logger" && isLogFunction(name):
+		return []string{writer}, allArgs(cc)
+	case path == "io" && recv == "" && name == "WriteString":
+		return []string{writerOutput(cc.Args[0])}, []int{1}
+	case (path == "os" || path == "io/ioutil") && recv == "" && name == "WriteFile":
+		return []string{file}, []int{1}
+	case path == "os" && recv == "*File" && isWriteMethod(name):
+		return []string{writerOutput(cc.Args[0])}, []int{0}
+	case path == "net" && recv != "" && isWriteMethod(name):
+		return []string{network}, []int{0}
+	case path == "bufio" && recv == "*Writer" && isWriteMethod(name):
+		return []string{writer}, []int{0}
+	case conf.IsSink(path, recv, name):
+		if arg, ok := conf.SinkArgument(path, recv, name); ok {
+			return []string{configuredSink}, []int{arg}
+		}
+		return []string{configuredSink}, allArgs(cc)
+	}
+	if w := lookup(callee); w != nil {
+		return w.Outputs, w.Params
+	}
+	return nil, nil
+}
+
+// allArgs returns the positions of all of the arguments of a call, not counting the receiver.
+func allArgs(cc *ssa.CallCommon) []int {
+	n := len(cc.Args)
+	if cc.Signature().Recv() != nil {
+		n--
+	}
+	args := make([]int, n)
+	for i := range args {
+		args[i] = i
+	}
+	return args
+}
+
+func isLogFunction(name string) bool {
+	return strings.HasPrefix(name, "Print") || strings.HasPrefix(name, "Fatal") || strings.HasPrefix(name, "Panic")
+}
+
+func isWriteMethod(name string) bool {
+	switch name {
+	case "Write", "WriteAt", "WriteString", "WriteByte", "WriteRune":
+		return true
+	}
+	return false
+}
+
+// writerOutput describes the output that a writer writes to.
+// Standard output and error are recognized when they are used directly, e.g. fmt.Fprintln(os.Stderr, x).
+func writerOutput(v ssa.Value) string {
+	if mi, ok := v.(*ssa.MakeInterface); ok {
+		v = mi.X
+	}
+	if load, ok := v.(*ssa.UnOp); ok {
+		if g, ok := load.X.(*ssa.Global); ok && g.Pkg != nil && g.Pkg.Pkg.Path() == "os" {
+			switch g.Name() {
+			case "Stdout":
+				return stdout
+			case "Stderr":
+				return stderr
+			}
+		}
+	}
+	named, ok := utils.Dereference(v.Type()).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return writer
+	}
+	switch {
+	case named.Obj().Pkg().Path() == "os" && named.Obj().Name() == "File":
+		return file
+	case named.Obj().Pkg().Path() == "net":
+		return network
+	}
+	return writer
+}
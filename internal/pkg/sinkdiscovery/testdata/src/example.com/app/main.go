@@ -0,0 +1,39 @@
+// Copyright 2021 Google LLC // want package:"candidate sinks: 10"
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main // want "candidate sinks, by number of call sites:\nSinks:\n- Package: \"example.com/logging\"\n  Receiver: \"\\*Logger\"\n  Method: \"Info\"\n  # 3 call sites, writes msg to an io.Writer\n- Package: \"example.com/logging\"\n  Receiver: \"\"\n  Method: \"Debugf\"\n  # 2 call sites, writes format, args to os.Stderr\n"
+
+import (
+	"fmt"
+	"os"
+
+	"example.com/logging"
+	"example.com/store"
+)
+
+func main() {
+	l := logging.New(os.Stdout, "app")
+	l.Info("starting")
+	l.Info("running")
+	l.Info("done")
+	logging.Debugf("%d args", len(os.Args))
+	logging.Debugf("%v", os.Args)
+	store.Save("state", nil)
+	store.Dump(l)
+	report("ok")
+}
+
+func report(status string) { // want "candidate sink: report writes status to os.Stdout" report:"writes parameters \\[0\\] to os.Stdout"
+	fmt.Println("status:", status)
+}
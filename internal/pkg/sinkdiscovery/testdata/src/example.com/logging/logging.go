@@ -0,0 +1,58 @@
+// Copyright 2021 Google LLC // want package:"candidate sinks: 5"
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+type Logger struct {
+	out    io.Writer
+	prefix string
+}
+
+func New(out io.Writer, prefix string) *Logger {
+	return &Logger{out: out, prefix: prefix}
+}
+
+func (l *Logger) Info(msg string) { // want "candidate sink: Info writes msg to an io.Writer" Info:"writes parameters \\[0\\] to an io.Writer"
+	fmt.Fprintln(l.out, l.prefix, msg)
+}
+
+func Debugf(format string, args ...interface{}) { // want "candidate sink: Debugf writes format, args to os.Stderr" Debugf:"writes parameters \\[0 1\\] to os.Stderr"
+	log.Printf(format, args...)
+}
+
+func Errorf(err error) { // want "candidate sink: Errorf writes err to os.Stderr" Errorf:"writes parameters \\[0\\] to os.Stderr"
+	fmt.Fprintf(os.Stderr, "error: %v\n", err)
+}
+
+func Audit(event string, verbose bool) { // want "candidate sink: Audit writes event to os.Stderr" Audit:"writes parameters \\[0\\] to os.Stderr"
+	if verbose {
+		println(event)
+	}
+}
+
+func Say(w io.Writer, msg string) { // want "candidate sink: Say writes msg to an io.Writer" Say:"writes parameters \\[1\\] to an io.Writer"
+	io.WriteString(w, msg)
+}
+
+func Format(v string) string {
+	return strings.ToUpper(v)
+}
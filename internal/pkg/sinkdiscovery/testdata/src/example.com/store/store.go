@@ -0,0 +1,50 @@
+// Copyright 2021 Google LLC // want package:"candidate sinks: 9"
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+
+	"example.com/logging"
+)
+
+func Save(name string, data []byte) error { // want "candidate sink: Save writes data to a file" Save:"writes parameters \\[1\\] to a file"
+	return ioutil.WriteFile(name, data, 0600)
+}
+
+func Send(conn net.Conn, payload string) { // want "candidate sink: Send writes payload to the network" Send:"writes parameters \\[1\\] to the network"
+	conn.Write([]byte(payload))
+}
+
+func Dump(v interface{}) { // want "candidate sink: Dump writes v to os.Stderr" Dump:"writes parameters \\[0\\] to os.Stderr"
+	logging.Errorf(fmt.Errorf("%v", v))
+}
+
+func Upload(data []byte) { // want Upload:"writes parameters \\[0\\] to os.Stdout"
+	os.Stdout.Write(data)
+}
+
+func Backup(data []byte) { // want "candidate sink: Backup writes data to a configured sink" Backup:"writes parameters \\[0\\] to a configured sink"
+	Upload(data)
+}
+
+func Hash(data []byte) []byte {
+	h := sha256.Sum256(data)
+	return h[:]
+}
@@ -21,41 +21,79 @@ import (
 	"github.com/google/go-flow-levee/internal/pkg/config"
 	"github.com/google/go-flow-levee/internal/pkg/fieldpropagator"
 	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
+	"github.com/google/go-flow-levee/internal/pkg/formatter"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/ssa"
 )
 
-type ResultType = map[*ssa.Function][]*Source
+// ResultType maps the name of each configuration to the Sources identified with it.
+// The top-level configuration is unnamed.
+type ResultType map[string]FuncSources
+
+// For returns the Sources identified with a configuration.
+func (r ResultType) For(conf *config.Config) FuncSources {
+	return r[conf.Name]
+}
+
+// FuncSources relates each Function to the Sources it contains.
+type FuncSources = map[*ssa.Function][]*Source
 
 var Analyzer = &analysis.Analyzer{
 	Name:       "source",
 	Doc:        "This analyzer identifies ssa.Values that are sources.",
 	Flags:      config.FlagSet,
 	Run:        run,
-	Requires:   []*analysis.Analyzer{buildssa.Analyzer, fieldtags.Analyzer, fieldpropagator.Analyzer},
+	Requires:   []*analysis.Analyzer{buildssa.Analyzer, fieldtags.Analyzer, fieldpropagator.Analyzer, formatter.Analyzer},
 	ResultType: reflect.TypeOf(new(ResultType)).Elem(),
+	FactTypes:  []analysis.Fact{new(isTaintedGlobal)},
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
 	ssaInput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
 	taggedFields := pass.ResultOf[fieldtags.Analyzer].(fieldtags.ResultType)
 	fieldPropagators := pass.ResultOf[fieldpropagator.Analyzer].(fieldpropagator.ResultType)
+	formatters := pass.ResultOf[formatter.Analyzer].(formatter.ResultType)
 
 	conf, err := config.ReadConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	sourceMap := identify(conf, ssaInput, taggedFields, fieldPropagators)
+	// Each profile is evaluated independently, so its Sources, including those
+	// arising from field propagators and tainted globals, are identified separately.
+	// Only the Sources identified with the top-level configuration are reported.
+	result := ResultType{}
+	for _, c := range conf.WithProfiles() {
+		c = c.ForPackage(pass.Pkg)
+		sourceMap := identify(c, ssaInput, taggedFields, fieldPropagators.For(c))
+		identifyGlobalSources(pass, c, ssaInput, taggedFields, formatters, sourceMap)
+		// Sources in excluded files are only dropped once global sources have been
+		// identified, so that excluded files still contribute facts.
+		removeExcludedFiles(pass, c, sourceMap)
+		result[c.Name] = sourceMap
+	}
 
-	for _, srcs := range sourceMap {
+	for _, srcs := range result.For(conf) {
 		for _, s := range srcs {
 			report(pass, s.Pos())
 		}
 	}
 
-	return sourceMap, nil
+	return result, nil
+}
+
+// removeExcludedFiles removes the functions declared in excluded files from sourceMap.
+func removeExcludedFiles(pass *analysis.Pass, conf *config.Config, sourceMap FuncSources) {
+	excluded := conf.ExcludedFiles(pass.Fset, pass.Files)
+	if len(excluded) == 0 {
+		return
+	}
+	for fn := range sourceMap {
+		if excluded[pass.Fset.File(fn.Pos())] {
+			delete(sourceMap, fn)
+		}
+	}
 }
 
 func report(pass *analysis.Pass, pos token.Pos) {
@@ -0,0 +1,255 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"go/token"
+	"go/types"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
+	"github.com/google/go-flow-levee/internal/pkg/formatter"
+	"github.com/google/go-flow-levee/internal/pkg/propagation"
+	"github.com/google/go-flow-levee/internal/pkg/utils"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// isTaintedGlobal is a fact attached to package-level variables
+// that may hold a value tainted by a Source. It records the names
+// of the configurations with which the variable is tainted.
+type isTaintedGlobal struct {
+	Configs []string
+}
+
+func (i isTaintedGlobal) AFact() {}
+
+func (i isTaintedGlobal) String() string {
+	return "tainted global"
+}
+
+// globalTaint tracks the package-level variables that may hold tainted values
+// with a given configuration.
+type globalTaint struct {
+	pass    *analysis.Pass
+	conf    *config.Config
+	tainted map[types.Object]bool
+}
+
+// isTainted determines whether a global is tainted, either because a tainted value
+// was stored into it in the current package, or because it was tainted in the package
+// that declares it.
+func (gt globalTaint) isTainted(g *ssa.Global) bool {
+	if gt.tainted[g.Object()] {
+		return true
+	}
+	fact := &isTaintedGlobal{}
+	if !gt.pass.ImportObjectFact(g.Object(), fact) {
+		return false
+	}
+	for _, name := range fact.Configs {
+		if name == gt.conf.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// markTainted records that a global is tainted. It returns true if the global
+// was not previously known to be tainted.
+func (gt globalTaint) markTainted(g *ssa.Global) bool {
+	if gt.isTainted(g) {
+		return false
+	}
+	gt.tainted[g.Object()] = true
+	// Facts can only be exported for objects that belong to the current package.
+	if g.Object().Pkg() == gt.pass.Pkg {
+		fact := &isTaintedGlobal{}
+		gt.pass.ImportObjectFact(g.Object(), fact)
+		fact.Configs = append(fact.Configs, gt.conf.Name)
+		gt.pass.ExportObjectFact(g.Object(), fact)
+	}
+	return true
+}
+
+// identifyGlobalSources finds Sources that arise from package-level variables.
+// When a tainted value is stored into a global, loads from that global in any
+// function become Sources themselves. Since a load from a tainted global may
+// in turn taint other globals, this process is repeated until no new tainted
+// globals are found. The Sources found are added to the provided sourceMap.
+// Propagation is intraprocedural, so taint is only propagated from the Sources
+// of the functions that write to a global, which most packages do not have.
+func identifyGlobalSources(pass *analysis.Pass, conf *config.Config, ssaInput *buildssa.SSA, taggedFields fieldtags.ResultType, formatters formatter.ResultType, sourceMap map[*ssa.Function][]*Source) {
+	gt := globalTaint{
+		pass:    pass,
+		conf:    conf,
+		tainted: map[types.Object]bool{},
+	}
+
+	// Globals tainted in imported packages may already have loads in this package.
+	pending := addGlobalLoadSources(conf, ssaInput, gt, sourceMap)
+	writers := functionsWritingGlobals(ssaInput)
+	if len(writers) == 0 {
+		return
+	}
+	for fn, sources := range sourceMap {
+		pending[fn] = sources
+	}
+
+	for len(pending) > 0 {
+		newlyTainted := false
+		for fn, sources := range pending {
+			if !writers[fn] {
+				continue
+			}
+			for _, s := range sources {
+				prop := propagation.Taint(s.Node, conf, taggedFields, formatters)
+				for _, g := range globalsWrittenBy(fn, prop) {
+					if gt.markTainted(g) {
+						newlyTainted = true
+					}
+				}
+			}
+		}
+		pending = map[*ssa.Function][]*Source{}
+		if newlyTainted {
+			pending = addGlobalLoadSources(conf, ssaInput, gt, sourceMap)
+		}
+	}
+}
+
+// addGlobalLoadSources adds a Source to sourceMap for each load of a tainted global
+// that is not already a Source. The added Sources are returned.
+func addGlobalLoadSources(conf *config.Config, ssaInput *buildssa.SSA, gt globalTaint, sourceMap map[*ssa.Function][]*Source) map[*ssa.Function][]*Source {
+	added := map[*ssa.Function][]*Source{}
+	for _, fn := range ssaInput.SrcFuncs {
+		path, recv, name := utils.DecomposeFunction(fn)
+		if conf.IsSink(path, recv, name) || conf.IsExcluded(path, recv, name) {
+			continue
+		}
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				load, ok := instr.(*ssa.UnOp)
+				if !ok || load.Op != token.MUL {
+					continue
+				}
+				g := addressedGlobal(load.X)
+				if g == nil || !gt.isTainted(g) || isSource(load, sourceMap[fn]) {
+					continue
+				}
+				s := New(load)
+				sourceMap[fn] = append(sourceMap[fn], s)
+				added[fn] = append(added[fn], s)
+			}
+		}
+	}
+	return added
+}
+
+// functionsWritingGlobals returns the functions that write to a global,
+// as described by globalsWrittenBy.
+func functionsWritingGlobals(ssaInput *buildssa.SSA) map[*ssa.Function]bool {
+	writers := map[*ssa.Function]bool{}
+	for _, fn := range ssaInput.SrcFuncs {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				if globalWrittenBy(instr) != nil {
+					writers[fn] = true
+				}
+			}
+		}
+	}
+	return writers
+}
+
+// globalsWrittenBy returns the globals into which the Propagation writes a tainted value
+// within the given function. The following writes are considered:
+// - a Store into a global, e.g. "global = tainted"
+// - a Store into a field or element of a global, e.g. "global.Field = tainted"
+// - a MapUpdate on a map held by a global, e.g. "global[key] = tainted"
+func globalsWrittenBy(fn *ssa.Function, prop propagation.Propagation) []*ssa.Global {
+	var globals []*ssa.Global
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			g := globalWrittenBy(instr)
+			if g == nil || !prop.IsTainted(instr) {
+				continue
+			}
+			globals = append(globals, g)
+		}
+	}
+	return globals
+}
+
+// globalWrittenBy returns the global that an instruction writes to, if any.
+func globalWrittenBy(instr ssa.Instruction) *ssa.Global {
+	switch t := instr.(type) {
+	case *ssa.Store:
+		return rootGlobal(t.Addr)
+	case *ssa.MapUpdate:
+		return rootGlobal(t.Map)
+	}
+	return nil
+}
+
+// addressedGlobal returns the global that an address points into, if any,
+// e.g. the global "cache" for the address of "cache.Token" or "cache.tokens[0]".
+func addressedGlobal(addr ssa.Value) *ssa.Global {
+	for {
+		switch a := addr.(type) {
+		case *ssa.Global:
+			return a
+		case *ssa.FieldAddr:
+			addr = a.X
+		case *ssa.IndexAddr:
+			addr = a.X
+		default:
+			return nil
+		}
+	}
+}
+
+// rootGlobal returns the global that a written address or map is reached from, if any.
+// Unlike addressedGlobal, it follows loads, e.g. of the map in "global[key] = tainted"
+// or of the slice in "global[i] = tainted".
+func rootGlobal(v ssa.Value) *ssa.Global {
+	for {
+		switch t := v.(type) {
+		case *ssa.Global:
+			return t
+		case *ssa.FieldAddr:
+			v = t.X
+		case *ssa.IndexAddr:
+			v = t.X
+		case *ssa.UnOp:
+			if t.Op != token.MUL {
+				return nil
+			}
+			v = t.X
+		default:
+			return nil
+		}
+	}
+}
+
+func isSource(n ssa.Node, sources []*Source) bool {
+	for _, s := range sources {
+		if s.Node == n {
+			return true
+		}
+	}
+	return false
+}
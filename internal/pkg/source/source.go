@@ -16,12 +16,14 @@
 package source
 
 import (
+	"go/constant"
 	"go/token"
 	"go/types"
 
 	"github.com/google/go-flow-levee/internal/pkg/config"
 	"github.com/google/go-flow-levee/internal/pkg/fieldpropagator"
 	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
+	"github.com/google/go-flow-levee/internal/pkg/propagation/summary"
 	"github.com/google/go-flow-levee/internal/pkg/sourcetype"
 	"github.com/google/go-flow-levee/internal/pkg/utils"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
@@ -61,10 +63,18 @@ func New(in ssa.Node) *Source {
 	}
 }
 
+// Identify identifies the Sources in a package, using a configuration
+// other than the one shared by the analyzers, e.g. a built-in one.
+// Since field propagators and tainted package-level variables are identified
+// using the shared configuration, they are not taken into account.
+func Identify(conf *config.Config, ssaInput *buildssa.SSA, taggedFields fieldtags.ResultType) FuncSources {
+	return identify(conf, ssaInput, taggedFields, fieldpropagator.Propagators{})
+}
+
 // identify individually examines each Function in the SSA code looking for Sources.
 // It produces a map relating a Function to the Sources it contains.
 // If a Function contains no Sources, it does not appear in the map.
-func identify(conf *config.Config, ssaInput *buildssa.SSA, taggedFields fieldtags.ResultType, propagators fieldpropagator.ResultType) map[*ssa.Function][]*Source {
+func identify(conf *config.Config, ssaInput *buildssa.SSA, taggedFields fieldtags.ResultType, propagators fieldpropagator.Propagators) map[*ssa.Function][]*Source {
 	sourceMap := make(map[*ssa.Function][]*Source)
 
 	for _, fn := range ssaInput.SrcFuncs {
@@ -89,14 +99,47 @@ func identify(conf *config.Config, ssaInput *buildssa.SSA, taggedFields fieldtag
 // sourcesFromParams identifies Sources that appear within a Function's parameters.
 func sourcesFromParams(fn *ssa.Function, conf *config.Config, taggedFields fieldtags.ResultType) []*Source {
 	var sources []*Source
-	for _, p := range fn.Params {
-		if sourcetype.IsSourceType(conf, taggedFields, p.Type()) {
+	for i, p := range fn.Params {
+		if sourcetype.IsSourceType(conf, taggedFields, p.Type()) || isSourceParam(fn, i, conf) {
 			sources = append(sources, New(p))
 		}
 	}
 	return sources
 }
 
+// isSourceParam determines whether the i-th parameter of a function,
+// including the receiver, is configured as a source.
+func isSourceParam(fn *ssa.Function, i int, conf *config.Config) bool {
+	if len(conf.SourceParameters) == 0 {
+		return false
+	}
+	p := fn.Params[i]
+	// The receiver is not counted as a parameter.
+	if fn.Signature.Recv() != nil {
+		if i == 0 {
+			return false
+		}
+		i--
+	}
+	path, recv, name := utils.DecomposeFunction(fn)
+	return conf.IsSourceParameter(path, recv, name, signatureString(fn.Signature), i, p.Name())
+}
+
+// signatureString returns a function's signature without parameter names or receiver,
+// e.g. "func(net/http.ResponseWriter, *net/http.Request)".
+func signatureString(sig *types.Signature) string {
+	params := make([]*types.Var, sig.Params().Len())
+	for i := range params {
+		params[i] = types.NewParam(token.NoPos, nil, "", sig.Params().At(i).Type())
+	}
+	results := make([]*types.Var, sig.Results().Len())
+	for i := range results {
+		results[i] = types.NewParam(token.NoPos, nil, "", sig.Results().At(i).Type())
+	}
+	unnamed := types.NewSignature(nil, types.NewTuple(params...), types.NewTuple(results...), sig.Variadic())
+	return types.TypeString(unnamed, nil)
+}
+
 // sourcesFromClosures identifies Source values which are captured by closures.
 // A value that is captured by a closure will appear as a Free Variable in the
 // closure. In the SSA, a Free Variable is represented as a Pointer, distinct
@@ -112,7 +155,7 @@ func sourcesFromClosures(fn *ssa.Function, conf *config.Config, taggedFields fie
 }
 
 // sourcesFromBlocks finds Source values created by instructions within a function's body.
-func sourcesFromBlocks(fn *ssa.Function, conf *config.Config, taggedFields fieldtags.ResultType, propagators fieldpropagator.ResultType) []*Source {
+func sourcesFromBlocks(fn *ssa.Function, conf *config.Config, taggedFields fieldtags.ResultType, propagators fieldpropagator.Propagators) []*Source {
 	var sources []*Source
 	for _, b := range fn.Blocks {
 		for _, instr := range b.Instrs {
@@ -124,7 +167,7 @@ func sourcesFromBlocks(fn *ssa.Function, conf *config.Config, taggedFields field
 	return sources
 }
 
-func isSourceNode(n ssa.Node, conf *config.Config, propagators fieldpropagator.ResultType, taggedFields fieldtags.ResultType) bool {
+func isSourceNode(n ssa.Node, conf *config.Config, propagators fieldpropagator.Propagators, taggedFields fieldtags.ResultType) bool {
 	switch v := n.(type) {
 	// All sources are explicitly identified.
 	default:
@@ -135,10 +178,11 @@ func isSourceNode(n ssa.Node, conf *config.Config, propagators fieldpropagator.R
 		return !isProducedBySanitizer(v, conf) && sourcetype.IsSourceType(conf, taggedFields, n.(ssa.Value).Type())
 
 	// Values produced by sanitizers are not sources.
-	// Values produced by field propagators are.
+	// Values produced by field propagators and source functions are, as are
+	// structured logging attributes with a sensitive key.
 	case *ssa.Call:
 		return !isProducedBySanitizer(v, conf) &&
-			(propagators.IsFieldPropagator(v) || sourcetype.IsSourceType(conf, taggedFields, n.(ssa.Value).Type()))
+			(propagators.IsFieldPropagator(v) || sourcetype.IsSourceType(conf, taggedFields, n.(ssa.Value).Type()) || isSourceCall(v, conf) || isSensitiveAttribute(v, conf))
 
 	// A type assertion can assert that an interface is of a source type.
 	// Only panicky type asserts will refer to the source Value.
@@ -156,18 +200,127 @@ func isSourceNode(n ssa.Node, conf *config.Config, propagators fieldpropagator.R
 		return sourcetype.IsSourceType(conf, taggedFields, t)
 
 	// Unary operator <- can receive sources from a channel.
+	// Unary operator * can load a source from a package-level variable, e.g. os.Args.
 	case *ssa.UnOp:
-		return v.Op == token.ARROW && sourcetype.IsSourceType(conf, taggedFields, n.(ssa.Value).Type())
+		return v.Op == token.ARROW && sourcetype.IsSourceType(conf, taggedFields, n.(ssa.Value).Type()) ||
+			v.Op == token.MUL && isSourceVariable(v.X, conf)
 
 	// Field access (Field, FieldAddr),
 	// collection access (Index, IndexAddr, Lookup),
 	// and mutable collection instantiation (MakeMap, MakeChan)
 	// are considered sources if they are of source type.
 	case *ssa.Field, *ssa.FieldAddr,
-		*ssa.Index, *ssa.IndexAddr, *ssa.Lookup,
-		*ssa.MakeMap, *ssa.MakeChan:
+		*ssa.Index, *ssa.IndexAddr,
+		*ssa.MakeChan:
 		return sourcetype.IsSourceType(conf, taggedFields, n.(ssa.Value).Type())
+
+	// A map lookup is also a source if its key is a constant
+	// that is configured as a source for the map's type.
+	case *ssa.Lookup:
+		return sourcetype.IsSourceType(conf, taggedFields, v.Type()) || isSourceLookup(v, conf)
+
+	// A map of structured logging attributes is also a source
+	// if one of its keys is sensitive.
+	case *ssa.MakeMap:
+		return sourcetype.IsSourceType(conf, taggedFields, v.Type()) || isSensitiveAttributeMap(v, conf)
+	}
+}
+
+// isSourceCall determines whether a call is to a source function,
+// taking the values of its constant arguments into account,
+// e.g. os.Getenv("DB_PASSWORD").
+func isSourceCall(call *ssa.Call, conf *config.Config) bool {
+	callee := call.Call.StaticCallee()
+	if callee == nil || len(conf.SourceFunctions) == 0 {
+		return false
+	}
+	args := call.Call.Args
+	// The receiver is not counted as an argument.
+	if callee.Signature.Recv() != nil && len(args) > 0 {
+		args = args[1:]
+	}
+	constArgs := map[int]string{}
+	for i, a := range args {
+		if c, ok := a.(*ssa.Const); ok {
+			if val, ok := constantValue(c); ok {
+				constArgs[i] = val
+			}
+		}
+	}
+	path, recv, name := utils.DecomposeFunction(callee)
+	return conf.IsSourceCall(path, recv, name, constArgs)
+}
+
+// isSourceVariable determines whether a value is a package-level variable
+// that is configured as a source.
+func isSourceVariable(v ssa.Value, conf *config.Config) bool {
+	g, ok := v.(*ssa.Global)
+	if !ok || g.Pkg == nil || len(conf.SourceVariables) == 0 {
+		return false
 	}
+	return conf.IsSourceVariable(g.Pkg.Pkg.Path(), g.Name())
+}
+
+// isSourceLookup determines whether a map lookup uses a constant key
+// that is configured as a source for the map's type, e.g. header["Cookie"].
+func isSourceLookup(lookup *ssa.Lookup, conf *config.Config) bool {
+	if _, ok := lookup.X.Type().Underlying().(*types.Map); !ok || len(conf.SourceMapLookups) == 0 {
+		return false
+	}
+	c, ok := lookup.Index.(*ssa.Const)
+	if !ok {
+		return false
+	}
+	key, ok := constantValue(c)
+	if !ok {
+		return false
+	}
+	path, name := utils.DecomposeType(lookup.X.Type())
+	return conf.IsSourceLookup(path, name, key)
+}
+
+// constantValue returns the value of a constant as a string.
+// String constants are unquoted. ok is false if the constant is nil.
+func constantValue(c *ssa.Const) (val string, ok bool) {
+	if c.Value == nil {
+		return "", false
+	}
+	if c.Value.Kind() == constant.String {
+		return constant.StringVal(c.Value), true
+	}
+	return c.Value.ExactString(), true
+}
+
+// isSensitiveAttribute determines whether a call constructs a structured logging
+// attribute whose key is a constant that is configured as sensitive,
+// e.g. slog.String("password", value).
+func isSensitiveAttribute(call *ssa.Call, conf *config.Config) bool {
+	key, ok := summary.AttributeKey(call).(*ssa.Const)
+	return ok && isSensitiveKey(key, conf)
+}
+
+// isSensitiveAttributeMap determines whether a map of structured logging attributes
+// is assigned a constant key that is configured as sensitive,
+// e.g. logrus.Fields{"password": value}.
+func isSensitiveAttributeMap(mm *ssa.MakeMap, conf *config.Config) bool {
+	path, name := utils.DecomposeType(mm.Type())
+	if !summary.AttributeMapTypes[path+"."+name] || mm.Referrers() == nil {
+		return false
+	}
+	for _, r := range *mm.Referrers() {
+		update, ok := r.(*ssa.MapUpdate)
+		if !ok || update.Map != mm {
+			continue
+		}
+		if key, ok := update.Key.(*ssa.Const); ok && isSensitiveKey(key, conf) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSensitiveKey(key *ssa.Const, conf *config.Config) bool {
+	return key.Value != nil && key.Value.Kind() == constant.String && conf.IsSensitiveKey(constant.StringVal(key.Value))
 }
 
 func isProducedBySanitizer(v ssa.Value, conf *config.Config) bool {
@@ -0,0 +1,200 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sourcediscovery defines an analyzer that suggests Sources,
+// by finding struct fields whose name, type or tag looks sensitive.
+// Suggestions are reported as YAML snippets, ready to be reviewed and
+// added to the configuration.
+package sourcediscovery
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
+	"github.com/google/go-flow-levee/internal/pkg/sourcetype"
+	"golang.org/x/tools/go/analysis"
+)
+
+// A Candidate is a struct field that looks sensitive according to the configured heuristics.
+type Candidate struct {
+	Type  *types.Named
+	Field *types.Var
+	Score int
+	// Tags are the key-value pairs of the field's tag that matched a heuristic.
+	// If there are any, the field is suggested by its tags.
+	Tags []config.FieldTag
+	// Covered is true if the field is already a Source according to the configuration.
+	Covered bool
+}
+
+// ResultType is the list of candidates found in a package,
+// ordered by type and field declaration.
+type ResultType []Candidate
+
+var Analyzer = &analysis.Analyzer{
+	Name: "sourcediscovery",
+	Doc: `suggests Sources by finding fields that look sensitive
+
+Struct fields are scored according to the SourceHeuristics of the configuration,
+which match their name, type and tag. Fields whose score reaches the
+SourceSuggestionThreshold, and that are not already Sources, are reported
+along with a YAML snippet of Sources or FieldTags that would identify them.`,
+	Flags:      config.FlagSet,
+	Run:        run,
+	Requires:   []*analysis.Analyzer{fieldtags.Analyzer},
+	ResultType: reflect.TypeOf(new(ResultType)).Elem(),
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	conf, err := config.ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+	taggedFields := pass.ResultOf[fieldtags.Analyzer].(fieldtags.ResultType)
+	excludedFiles := conf.ExcludedFiles(pass.Fset, pass.Files)
+
+	var result ResultType
+	scope := pass.Pkg.Scope()
+	// Names are sorted, which makes reports deterministic.
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() || excludedFiles[pass.Fset.File(tn.Pos())] {
+			continue
+		}
+		named, ok := tn.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		candidates := findCandidates(conf, taggedFields, named)
+		result = append(result, candidates...)
+		if snippet := Suggest(candidates); snippet != "" {
+			pass.Reportf(tn.Pos(), "%s", describe(named, candidates)+"\n"+snippet)
+		}
+	}
+	return result, nil
+}
+
+// findCandidates returns the fields of a struct type that look sensitive.
+// Fields that are tagged as safe have been reviewed, and are never candidates.
+func findCandidates(conf *config.Config, taggedFields fieldtags.ResultType, named *types.Named) []Candidate {
+	s, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	var candidates []Candidate
+	for i := 0; i < s.NumFields(); i++ {
+		field := s.Field(i)
+		if field.Embedded() || taggedFields.IsSafe(field) || conf.IsSafeFieldTag(s.Tag(i)) {
+			continue
+		}
+		score, tags := conf.ScoreSourceField(field.Name(), types.TypeString(field.Type(), nil), s.Tag(i))
+		if !conf.IsSuggestedSourceScore(score) {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			Type:    named,
+			Field:   field,
+			Score:   score,
+			Tags:    tags,
+			Covered: isCovered(conf, taggedFields, named, i),
+		})
+	}
+	return candidates
+}
+
+// isCovered determines whether a field is a Source according to the configuration
+// or one of its profiles.
+func isCovered(conf *config.Config, taggedFields fieldtags.ResultType, named *types.Named, field int) bool {
+	if sourcetype.IsSourceField(conf, taggedFields, named, field) {
+		return true
+	}
+	for _, p := range conf.Profiles {
+		if sourcetype.IsSourceField(p, taggedFields, named, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe summarizes the candidates of a type, e.g.
+// "suggested sources for Credentials (already covered: Password):".
+func describe(named *types.Named, candidates []Candidate) string {
+	var covered []string
+	for _, c := range candidates {
+		if c.Covered {
+			covered = append(covered, c.Field.Name())
+		}
+	}
+	if len(covered) == 0 {
+		return fmt.Sprintf("suggested sources for %s:", named.Obj().Name())
+	}
+	return fmt.Sprintf("suggested sources for %s (already covered: %s):", named.Obj().Name(), strings.Join(covered, ", "))
+}
+
+// Suggest returns a YAML snippet of the Sources and FieldTags that would identify
+// the candidates that are not covered yet, or "" if all of them are covered.
+// Candidates are grouped by type, and fields that are suggested by their tags
+// are identified by these tags rather than by their names.
+func Suggest(candidates []Candidate) string {
+	var named []*types.Named
+	fieldsByType := map[*types.Named][]string{}
+	tagSeen := map[config.FieldTag]bool{}
+	var tags []config.FieldTag
+	for _, c := range candidates {
+		switch {
+		case c.Covered:
+		case len(c.Tags) > 0:
+			for _, t := range c.Tags {
+				if !tagSeen[t] {
+					tagSeen[t] = true
+					tags = append(tags, t)
+				}
+			}
+		default:
+			if _, ok := fieldsByType[c.Type]; !ok {
+				named = append(named, c.Type)
+			}
+			fieldsByType[c.Type] = append(fieldsByType[c.Type], c.Field.Name())
+		}
+	}
+
+	var b strings.Builder
+	if len(named) > 0 {
+		b.WriteString("Sources:\n")
+	}
+	for _, t := range named {
+		fields := fieldsByType[t]
+		fmt.Fprintf(&b, "- Package: %q\n", t.Obj().Pkg().Path())
+		fmt.Fprintf(&b, "  Type: %q\n", t.Obj().Name())
+		if len(fields) == 1 {
+			fmt.Fprintf(&b, "  Field: %q\n", fields[0])
+		} else {
+			sort.Strings(fields)
+			fmt.Fprintf(&b, "  FieldRE: %q\n", "^("+strings.Join(fields, "|")+")$")
+		}
+	}
+	if len(tags) > 0 {
+		b.WriteString("FieldTags:\n")
+	}
+	for _, t := range tags {
+		fmt.Fprintf(&b, "- Key: %q\n", t.Key)
+		fmt.Fprintf(&b, "  Value: %q\n", t.Value)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
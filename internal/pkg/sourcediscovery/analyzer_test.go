@@ -0,0 +1,77 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourcediscovery
+
+import (
+	"go/types"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestSourceDiscovery(t *testing.T) {
+	testdata := analysistest.TestData()
+	if err := config.FlagSet.Set("config", filepath.Join(testdata, "test-config.yaml")); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, testdata, Analyzer, "example.com/app")
+}
+
+func TestConfiguredHeuristics(t *testing.T) {
+	testdata := analysistest.TestData()
+	if err := config.FlagSet.Set("config", filepath.Join(testdata, "heuristics-config.yaml")); err != nil {
+		t.Error(err)
+	}
+	analysistest.Run(t, testdata, Analyzer, "example.com/custom")
+}
+
+func TestSuggestionsAreValidConfig(t *testing.T) {
+	testdata := analysistest.TestData()
+	if err := config.FlagSet.Set("config", filepath.Join(testdata, "test-config.yaml")); err != nil {
+		t.Error(err)
+	}
+	results := analysistest.Run(t, testdata, Analyzer, "example.com/app")
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	candidates := results[0].Result.(ResultType)
+
+	conf, err := config.Parse([]byte(Suggest(candidates)))
+	if err != nil {
+		t.Fatalf("suggestions are not a valid configuration: %v", err)
+	}
+	for _, c := range candidates {
+		if c.Covered {
+			continue
+		}
+		path, typeName := c.Type.Obj().Pkg().Path(), c.Type.Obj().Name()
+		if !conf.IsSourceField(path, typeName, c.Field.Name()) && !conf.IsSourceFieldTag(fieldTag(c)) {
+			t.Errorf("field %s.%s is not a source according to the suggestions", typeName, c.Field.Name())
+		}
+	}
+}
+
+// fieldTag returns the tag of the field of a candidate.
+func fieldTag(c Candidate) string {
+	s := c.Type.Underlying().(*types.Struct)
+	for i := 0; i < s.NumFields(); i++ {
+		if s.Field(i) == c.Field {
+			return s.Tag(i)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"crypto/rsa"
+	"time"
+)
+
+type Credentials struct { // want "suggested sources for Credentials \\(already covered: Password\\):\nSources:\n- Package: \"example.com/app\"\n  Type: \"Credentials\"\n  FieldRE: \"\\^\\(ClientSecret\\|PrivateKey\\)\\$\""
+	User         string
+	Password     string
+	ClientSecret string
+	PrivateKey   *rsa.PrivateKey
+	HasPassword  bool
+	SecretName   string
+	TokenExpiry  time.Time
+	PasswordHint string `levee:"safe"`
+}
+
+type Profile struct { // want "suggested sources for Profile:\nSources:\n- Package: \"example.com/app\"\n  Type: \"Profile\"\n  Field: \"Phone\"\nFieldTags:\n- Key: \"datapolicy\"\n  Value: \"pii\""
+	Name    string
+	Email   string `datapolicy:"pii"`
+	Address string `datapolicy:"pii"`
+	Phone   string
+}
+
+type Config struct {
+	APIKey string
+}
+
+type Session struct {
+	Token string `levee:"source"`
+}
+
+type Settings struct {
+	Timeout  time.Duration
+	Endpoint string
+}
+
+type Token string
@@ -27,13 +27,14 @@ import (
 // IsSourceType determines whether a Type is a Source Type.
 // A Source Type is either:
 // - A Named Struct Type that is configured as a Source
+// - A Named Type that implements a configured Source interface
 // - A Struct Type that contains a tagged field
 // - A composite type that contains a Source Type
 func IsSourceType(c *config.Config, tf fieldtags.ResultType, t types.Type) bool {
 	deref := utils.Dereference(t)
 	switch tt := deref.(type) {
 	case *types.Named:
-		return c.IsSourceType(utils.DecomposeType(tt)) || IsSourceType(c, tf, tt.Underlying())
+		return c.IsSourceType(utils.DecomposeType(tt)) || ImplementsSourceInterface(c, tt) || IsSourceType(c, tf, tt.Underlying())
 	case *types.Array:
 		return IsSourceType(c, tf, tt.Elem())
 	case *types.Slice:
@@ -68,3 +69,22 @@ func hasTaggedField(taggedFields fieldtags.ResultType, s *types.Struct) bool {
 	}
 	return false
 }
+
+// IsSourceField determines whether a field of a struct type is a Source field,
+// i.e. whether it is configured or tagged as a Source, and is not tagged as safe.
+// The type may be a pointer to a struct type.
+func IsSourceField(c *config.Config, tf fieldtags.ResultType, t types.Type, field int) bool {
+	if tf.IsSafeField(t, field) {
+		return false
+	}
+	// All of the fields of a type that implements a Source interface are Source fields,
+	// as for a type that is configured as a Source without specifying its fields.
+	return c.IsSourceField(utils.DecomposeField(t, field)) || tf.IsSourceField(t, field) || ImplementsSourceInterface(c, t)
+}
+
+// ImplementsSourceInterface determines whether a Named Type, or a pointer to it,
+// implements one of the configured Source interfaces.
+func ImplementsSourceInterface(c *config.Config, t types.Type) bool {
+	named, ok := utils.Dereference(t).(*types.Named)
+	return ok && c.ImplementsSourceInterface(named)
+}
@@ -0,0 +1,183 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package summarygen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/printer"
+	"go/types"
+	"strings"
+
+	"github.com/google/go-flow-levee/internal/pkg/utils"
+)
+
+// positionNames are the names of the constants used for IfTainted in the summary package.
+var positionNames = []string{"first", "second", "third", "fourth"}
+
+// sharedSummaries are the summaries that are declared once in the summary package,
+// keyed by their IfTainted, TaintedArgs and TaintedRets.
+var sharedSummaries = map[string]string{
+	"first [] [0]":  "fromFirstArgToFirstRet",
+	"second [] [0]": "fromSecondArgToFirstRet",
+	"second [0] []": "fromSecondArgToFirstArg",
+	"third [1] []":  "fromThirdArgToSecondArg",
+	"first [] [1]":  "fromFirstArgToSecondRet",
+}
+
+// FormatGo formats results as entries of summary.FuncSummaries.
+// Each entry is preceded by the signature of its function, and by the reasons
+// to review it, if any. Functions that do not propagate taint are omitted,
+// unless they need to be reviewed, in which case they are only described by comments,
+// since taint may have been lost.
+func FormatGo(results []Result) string {
+	var b strings.Builder
+	for _, r := range results {
+		if !r.Propagates() && len(r.Review) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "// %s\n", signature(r))
+		for _, reason := range r.Review {
+			fmt.Fprintf(&b, "// Review: %s.\n", reason)
+		}
+		if !r.Propagates() {
+			fmt.Fprintf(&b, "// %q: no propagation was found.\n", r.Key())
+			continue
+		}
+		if shared, ok := sharedSummaries[summaryKey(r)]; ok {
+			fmt.Fprintf(&b, "%q: %s,\n", r.Key(), shared)
+			continue
+		}
+		fmt.Fprintf(&b, "%q: {\n", r.Key())
+		fmt.Fprintf(&b, "\tIfTainted: %s,\n", ifTainted(r.Summary.IfTainted))
+		if len(r.Summary.TaintedArgs) > 0 {
+			fmt.Fprintf(&b, "\tTaintedArgs: %s,\n", intSlice(r.Summary.TaintedArgs))
+		}
+		if len(r.Summary.TaintedRets) > 0 {
+			fmt.Fprintf(&b, "\tTaintedRets: %s,\n", intSlice(r.Summary.TaintedRets))
+		}
+		b.WriteString("},\n")
+	}
+	return gofmtEntries(b.String())
+}
+
+// gofmtEntries formats map entries as gofmt would inside the map literal,
+// e.g. by aligning the values of their fields.
+func gofmtEntries(entries string) string {
+	const prefix, suffix = "package p\n\nvar _ = map[string]Summary{\n", "}\n"
+	src, err := format.Source([]byte(prefix + entries + suffix))
+	if err != nil {
+		return entries
+	}
+	formatted := strings.TrimSuffix(strings.TrimPrefix(string(src), prefix), suffix)
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(formatted, "\n") {
+		b.WriteString(strings.TrimPrefix(line, "\t"))
+	}
+	return b.String()
+}
+
+// FormatConfig formats results as the Summaries of a configuration.
+// Functions that do not propagate taint are omitted, and the reasons
+// to review a summary are written as YAML comments.
+func FormatConfig(results []Result) string {
+	var b strings.Builder
+	for _, r := range results {
+		if !r.Propagates() {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString("Summaries:\n")
+		}
+		for _, reason := range r.Review {
+			fmt.Fprintf(&b, "# Review: %s.\n", reason)
+		}
+		path, recv, name := utils.DecomposeFunction(r.Function)
+		fmt.Fprintf(&b, "- Package: %q\n", path)
+		if recv != "" {
+			fmt.Fprintf(&b, "  Receiver: %q\n", recv)
+		}
+		fmt.Fprintf(&b, "  Method: %q\n", name)
+		fmt.Fprintf(&b, "  IfTainted: %s\n", yamlList(positions(r.Summary.IfTainted)))
+		if len(r.Summary.TaintedArgs) > 0 {
+			fmt.Fprintf(&b, "  TaintedArgs: %s\n", yamlList(r.Summary.TaintedArgs))
+		}
+		if len(r.Summary.TaintedRets) > 0 {
+			fmt.Fprintf(&b, "  TaintedRets: %s\n", yamlList(r.Summary.TaintedRets))
+		}
+	}
+	return b.String()
+}
+
+func summaryKey(r Result) string {
+	return fmt.Sprintf("%s %v %v", ifTainted(r.Summary.IfTainted), r.Summary.TaintedArgs, r.Summary.TaintedRets)
+}
+
+// ifTainted formats a bitset of positions, e.g. "first | third" or "first | 1<<5".
+func ifTainted(bits int64) string {
+	var terms []string
+	for _, p := range positions(bits) {
+		if p < len(positionNames) {
+			terms = append(terms, positionNames[p])
+		} else {
+			terms = append(terms, fmt.Sprintf("1<<%d", p))
+		}
+	}
+	return strings.Join(terms, " | ")
+}
+
+func positions(bits int64) []int {
+	var ps []int
+	for p := 0; p < maxParams; p++ {
+		if bits&(1<<p) != 0 {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+func intSlice(s []int) string {
+	return "[]int{" + joinInts(s) + "}"
+}
+
+func yamlList(s []int) string {
+	return "[" + joinInts(s) + "]"
+}
+
+func joinInts(s []int) string {
+	var elems []string
+	for _, i := range s {
+		elems = append(elems, fmt.Sprint(i))
+	}
+	return strings.Join(elems, ", ")
+}
+
+// signature returns the declaration of a function without its body,
+// e.g. "func (c *Client) Encode(s string) string".
+func signature(r Result) string {
+	fn := r.Function
+	if decl, ok := fn.Syntax().(*ast.FuncDecl); ok {
+		withoutBody := *decl
+		withoutBody.Doc = nil
+		withoutBody.Body = nil
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, fn.Prog.Fset, &withoutBody); err == nil {
+			return buf.String()
+		}
+	}
+	return "func " + fn.Name() + strings.TrimPrefix(types.TypeString(fn.Signature, types.RelativeTo(fn.Pkg.Pkg)), "func")
+}
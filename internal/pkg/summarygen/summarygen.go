@@ -0,0 +1,308 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package summarygen computes propagation summaries for the exported functions
+// and methods of a package, using levee's propagation engine.
+// Taint is propagated from each parameter in turn, and the parameters and
+// return values that it reaches make up the function's summary.
+// Functions whose behavior cannot be summarized precisely are flagged,
+// so that their summaries can be reviewed before they are used.
+package summarygen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/fieldtags"
+	"github.com/google/go-flow-levee/internal/pkg/formatter"
+	"github.com/google/go-flow-levee/internal/pkg/propagation"
+	"github.com/google/go-flow-levee/internal/pkg/propagation/summary"
+	"github.com/google/go-flow-levee/internal/pkg/utils"
+	"golang.org/x/tools/go/ssa"
+)
+
+// A Result is the summary computed for a function.
+type Result struct {
+	Function *ssa.Function
+	Summary  summary.Summary
+	// Review lists the reasons why the summary may not be precise, if any.
+	Review []string
+}
+
+// Key returns the key of the function in summary.FuncSummaries,
+// e.g. "(*example.com/lib.Client).Encode".
+func (r Result) Key() string {
+	return r.Function.RelString(nil)
+}
+
+// Propagates determines whether taint propagates through the function at all.
+func (r Result) Propagates() bool {
+	return r.Summary.IfTainted != 0
+}
+
+// maxParams is the number of parameters that a summary's IfTainted bitset can describe.
+const maxParams = 64
+
+// Package computes the summaries of the exported functions of a package,
+// and of the exported methods of its exported types, ordered by key.
+// The Config provides the sanitizers and summaries used during propagation.
+func Package(conf *config.Config, pkg *ssa.Package) []Result {
+	var results []Result
+	for _, fn := range exportedFunctions(pkg) {
+		results = append(results, Function(conf, fn))
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Key() < results[j].Key() })
+	return results
+}
+
+func exportedFunctions(pkg *ssa.Package) []*ssa.Function {
+	var fns []*ssa.Function
+	seen := map[*ssa.Function]bool{}
+	for _, m := range pkg.Members {
+		switch m := m.(type) {
+		case *ssa.Function:
+			if m.Object() != nil && m.Object().Exported() {
+				fns = append(fns, m)
+			}
+		case *ssa.Type:
+			if !m.Object().Exported() {
+				continue
+			}
+			// Methods declared with a pointer receiver are only in the method set of the pointer type,
+			// which also contains synthetic wrappers for the methods declared with a value receiver.
+			for _, t := range []types.Type{m.Type(), types.NewPointer(m.Type())} {
+				mset := pkg.Prog.MethodSets.MethodSet(t)
+				for i := 0; i < mset.Len(); i++ {
+					sel := mset.At(i)
+					if !sel.Obj().Exported() || sel.Obj().Pkg() != pkg.Pkg {
+						continue
+					}
+					if fn := pkg.Prog.MethodValue(sel); fn != nil && fn.Synthetic == "" && !seen[fn] {
+						seen[fn] = true
+						fns = append(fns, fn)
+					}
+				}
+			}
+		}
+	}
+	return fns
+}
+
+// Function computes the summary of a function. The receiver of a method counts
+// as its first parameter, as in the summaries of the summary package.
+func Function(conf *config.Config, fn *ssa.Function) Result {
+	res := Result{Function: fn}
+	if len(fn.Blocks) == 0 {
+		res.Review = append(res.Review, "the function has no body")
+		return res
+	}
+	if len(fn.Params) > maxParams {
+		res.Review = append(res.Review, fmt.Sprintf("the function has more than %d parameters", maxParams))
+		return res
+	}
+
+	unsummarized := map[string]bool{}
+	var outputs []string
+	for i, p := range fn.Params {
+		args, rets := map[int]bool{}, map[int]bool{}
+		for _, prop := range taintParameter(conf, p) {
+			for k, q := range fn.Params {
+				if k != i && (prop.IsTaintedValue(q) || isStoredInto(prop, fn, q)) {
+					args[k] = true
+				}
+			}
+			for _, j := range taintedResults(prop, fn) {
+				rets[j] = true
+			}
+			for _, name := range unsummarizedCalls(conf, prop, fn) {
+				unsummarized[name] = true
+			}
+		}
+		if len(args) == 0 && len(rets) == 0 {
+			continue
+		}
+		res.Summary.IfTainted |= 1 << i
+		res.Summary.TaintedArgs = union(res.Summary.TaintedArgs, args)
+		res.Summary.TaintedRets = union(res.Summary.TaintedRets, rets)
+		outputs = append(outputs, fmt.Sprint(sorted(args), sorted(rets)))
+	}
+
+	for _, o := range outputs {
+		if o != outputs[0] {
+			res.Review = append(res.Review, "parameters taint different arguments or results; the summary over-approximates them")
+			break
+		}
+	}
+	if len(unsummarized) > 0 {
+		var names []string
+		for name := range unsummarized {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			res.Review = append(res.Review, fmt.Sprintf("taint reaches a call to %s, which has no summary", name))
+		}
+	}
+	return res
+}
+
+// taintParameter propagates taint from a parameter. A parameter that is stored
+// into a local variable, e.g. because its fields are accessed, is also propagated
+// from that variable.
+func taintParameter(conf *config.Config, p *ssa.Parameter) []propagation.Propagation {
+	props := []propagation.Propagation{propagation.Taint(p, conf, fieldtags.ResultType{}, formatter.ResultType{})}
+	if p.Referrers() == nil {
+		return props
+	}
+	for _, r := range *p.Referrers() {
+		if s, ok := r.(*ssa.Store); ok && s.Val == p {
+			if alloc, ok := s.Addr.(*ssa.Alloc); ok {
+				props = append(props, propagation.Taint(alloc, conf, fieldtags.ResultType{}, formatter.ResultType{}))
+			}
+		}
+	}
+	return props
+}
+
+// isStoredInto determines whether a tainted value is stored into memory
+// reached from a parameter, e.g. "*dst = v" or "dst.Field = v".
+func isStoredInto(prop propagation.Propagation, fn *ssa.Function, param *ssa.Parameter) bool {
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			switch s := instr.(type) {
+			case *ssa.Store:
+				if rootParameter(s.Addr) == param && prop.IsTaintedOperand(s, s.Val) {
+					return true
+				}
+			case *ssa.MapUpdate:
+				if rootParameter(s.Map) == param && (prop.IsTaintedOperand(s, s.Key) || prop.IsTaintedOperand(s, s.Value)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// rootParameter returns the parameter that an address is derived from, if any.
+func rootParameter(addr ssa.Value) *ssa.Parameter {
+	for {
+		switch a := addr.(type) {
+		case *ssa.FieldAddr:
+			addr = a.X
+		case *ssa.IndexAddr:
+			addr = a.X
+		case *ssa.UnOp:
+			if a.Op != token.MUL {
+				return nil
+			}
+			addr = a.X
+		case *ssa.Parameter:
+			return a
+		default:
+			return nil
+		}
+	}
+}
+
+// taintedResults returns the positions of the results that are tainted by a Propagation
+// in at least one of the function's return statements.
+func taintedResults(prop propagation.Propagation, fn *ssa.Function) []int {
+	var tainted []int
+	seen := map[int]bool{}
+	for _, b := range fn.Blocks {
+		ret, ok := b.Instrs[len(b.Instrs)-1].(*ssa.Return)
+		if !ok {
+			continue
+		}
+		for j, r := range ret.Results {
+			if !seen[j] && prop.IsTaintedOperand(ret, r) {
+				seen[j] = true
+				tainted = append(tainted, j)
+			}
+		}
+	}
+	sort.Ints(tainted)
+	return tainted
+}
+
+// unsummarizedCalls returns the names of the functions that receive tainted arguments,
+// but whose behavior is unknown to the propagation engine, so that taint may be lost.
+func unsummarizedCalls(conf *config.Config, prop propagation.Propagation, fn *ssa.Function) []string {
+	var names []string
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			call, ok := instr.(ssa.CallInstruction)
+			if !ok || !prop.IsTaintedCall(call, true) || isKnown(conf, call) {
+				continue
+			}
+			names = append(names, calleeName(call, fn.Pkg.Pkg))
+		}
+	}
+	return names
+}
+
+// isKnown determines whether the propagation engine knows how taint propagates through a call.
+func isKnown(conf *config.Config, call ssa.CallInstruction) bool {
+	cc := call.Common()
+	if _, ok := cc.Value.(*ssa.Builtin); ok {
+		return true
+	}
+	if summary.ExtendedFor(call) != nil {
+		return true
+	}
+	callee := cc.StaticCallee()
+	if callee == nil {
+		return false
+	}
+	path, recv, name := utils.DecomposeFunction(callee)
+	if _, ok := conf.Summary(path, recv, name); ok {
+		return true
+	}
+	return conf.IsSanitizer(path, recv, name) || (path == "context" && recv == "" && name == "WithValue")
+}
+
+func calleeName(call ssa.CallInstruction, from *types.Package) string {
+	cc := call.Common()
+	switch {
+	case cc.IsInvoke():
+		return fmt.Sprintf("(%s).%s", types.TypeString(cc.Value.Type(), types.RelativeTo(from)), cc.Method.Name())
+	case cc.StaticCallee() != nil:
+		return cc.StaticCallee().RelString(from)
+	}
+	return "a function value"
+}
+
+func union(positions []int, add map[int]bool) []int {
+	set := map[int]bool{}
+	for _, p := range positions {
+		set[p] = true
+	}
+	for p := range add {
+		set[p] = true
+	}
+	return sorted(set)
+}
+
+func sorted(set map[int]bool) []int {
+	var s []int
+	for p := range set {
+		s = append(s, p)
+	}
+	sort.Ints(s)
+	return s
+}
@@ -0,0 +1,196 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package summarygen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-flow-levee/internal/pkg/config"
+	"github.com/google/go-flow-levee/internal/pkg/propagation/summary"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+const src = `package lib
+
+import "strings"
+
+type Client struct {
+	Prefix string
+	parts  []string
+}
+
+func Encode(s string) string { return strings.ToUpper(s) }
+
+func Join(a, b string) string { return a + b }
+
+func Copy(dst *Client, s string) { dst.Prefix = s }
+
+func (c *Client) Add(s string) { c.parts = append(c.parts, s) }
+
+func (c Client) Describe(n int) string { return c.Prefix }
+
+func Mixed(a, b string) (string, string) { return a, b }
+
+func Opaque(s string) string { return unknown(s) }
+
+func Sanitized(s string) string { return Sanitize(s) }
+
+func Sanitize(s string) string { return "" }
+
+func Length(s string) int { return 0 }
+
+func unexported(s string) string { return s }
+
+type hidden struct{}
+
+func (hidden) Exported(s string) string { return s }
+
+var unknown = func(s string) string { return s }
+`
+
+func buildSSA(t *testing.T, src string) *ssa.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "lib.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := types.NewPackage("example.com/lib", "")
+	ssaPkg, _, err := ssautil.BuildPackage(
+		&types.Config{Importer: importer.ForCompiler(fset, "source", nil)}, fset, pkg, []*ast.File{f}, ssa.SanityCheckFunctions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ssaPkg
+}
+
+func TestPackage(t *testing.T) {
+	conf, err := config.Parse([]byte(`
+Sanitizers:
+- Package: example.com/lib
+  Method: Sanitize`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := Package(conf, buildSSA(t, src))
+
+	type got struct {
+		Summary summary.Summary
+		Review  int
+	}
+	gotByKey := map[string]got{}
+	var keys []string
+	for _, r := range results {
+		keys = append(keys, r.Key())
+		gotByKey[r.Key()] = got{r.Summary, len(r.Review)}
+	}
+
+	wantKeys := []string{
+		"(*example.com/lib.Client).Add",
+		"(example.com/lib.Client).Describe",
+		"example.com/lib.Copy",
+		"example.com/lib.Encode",
+		"example.com/lib.Join",
+		"example.com/lib.Length",
+		"example.com/lib.Mixed",
+		"example.com/lib.Opaque",
+		"example.com/lib.Sanitize",
+		"example.com/lib.Sanitized",
+	}
+	if diff := cmp.Diff(wantKeys, keys); diff != "" {
+		t.Errorf("summarized functions diff (-want +got):\n%s", diff)
+	}
+
+	want := map[string]got{
+		"(*example.com/lib.Client).Add":     {Summary: summary.Summary{IfTainted: 0b10, TaintedArgs: []int{0}}},
+		"(example.com/lib.Client).Describe": {Summary: summary.Summary{IfTainted: 0b1, TaintedRets: []int{0}}},
+		"example.com/lib.Copy":              {Summary: summary.Summary{IfTainted: 0b10, TaintedArgs: []int{0}}},
+		"example.com/lib.Encode":            {Summary: summary.Summary{IfTainted: 0b1, TaintedRets: []int{0}}},
+		"example.com/lib.Join":              {Summary: summary.Summary{IfTainted: 0b11, TaintedRets: []int{0}}},
+		"example.com/lib.Length":            {},
+		"example.com/lib.Mixed":             {Summary: summary.Summary{IfTainted: 0b11, TaintedRets: []int{0, 1}}, Review: 1},
+		"example.com/lib.Opaque":            {Review: 1},
+		"example.com/lib.Sanitize":          {},
+		"example.com/lib.Sanitized":         {},
+	}
+	for key, w := range want {
+		if diff := cmp.Diff(w, gotByKey[key]); diff != "" {
+			t.Errorf("%s: summary diff (-want +got):\n%s", key, diff)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	conf, err := config.Parse(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := buildSSA(t, `package lib
+
+func Encode(s string) string { return s }
+
+func Pick(a string, b int, c string) (string, error) { return c, nil }
+
+func Opaque(s string) string { return unknown(s) }
+
+var unknown = func(s string) string { return s }
+`)
+	results := Package(conf, pkg)
+
+	wantGo := `// func Encode(s string) string
+"example.com/lib.Encode": fromFirstArgToFirstRet,
+// func Opaque(s string) string
+// Review: taint reaches a call to a function value, which has no summary.
+// "example.com/lib.Opaque": no propagation was found.
+// func Pick(a string, b int, c string) (string, error)
+"example.com/lib.Pick": {
+	IfTainted:   third,
+	TaintedRets: []int{0},
+},
+`
+	if diff := cmp.Diff(wantGo, FormatGo(results)); diff != "" {
+		t.Errorf("Go output diff (-want +got):\n%s", diff)
+	}
+
+	wantConfig := `Summaries:
+- Package: "example.com/lib"
+  Method: "Encode"
+  IfTainted: [0]
+  TaintedRets: [0]
+- Package: "example.com/lib"
+  Method: "Pick"
+  IfTainted: [2]
+  TaintedRets: [0]
+`
+	if diff := cmp.Diff(wantConfig, FormatConfig(results)); diff != "" {
+		t.Errorf("config output diff (-want +got):\n%s", diff)
+	}
+
+	// The configured summaries can be read back.
+	parsed, err := config.Parse([]byte(FormatConfig(results)))
+	if err != nil {
+		t.Fatalf("parsing generated summaries: %v", err)
+	}
+	if s, ok := parsed.Summary("example.com/lib", "", "Pick"); !ok || !cmp.Equal(s.IfTainted, []int{2}) {
+		t.Errorf("generated summary for Pick = %v, %v", s, ok)
+	}
+}
@@ -19,11 +19,25 @@ import (
 	"github.com/google/go-flow-levee/internal/pkg/config"
 	"github.com/google/go-flow-levee/internal/pkg/levee"
 	"github.com/google/go-flow-levee/internal/pkg/propagation/summary"
+	"github.com/google/go-flow-levee/internal/pkg/sinkdiscovery"
+	"github.com/google/go-flow-levee/internal/pkg/sourcediscovery"
 )
 
 // Analyzer reports instances of source data reaching a sink.
 var Analyzer = levee.Analyzer
 
+// IntegrityAnalyzer reports instances of untrusted input reaching an injection sink,
+// such as a SQL query, a command, or a file path. It uses a built-in configuration.
+var IntegrityAnalyzer = levee.IntegrityAnalyzer
+
+// SourceDiscoveryAnalyzer suggests Sources, by reporting struct fields that look sensitive
+// along with YAML configuration snippets that would identify them.
+var SourceDiscoveryAnalyzer = sourcediscovery.Analyzer
+
+// SinkDiscoveryAnalyzer suggests Sinks, by reporting functions that write their parameters
+// to an output, ranked by the number of calls to each function.
+var SinkDiscoveryAnalyzer = sinkdiscovery.Analyzer
+
 // SetBytes is a wrapper around the config package's SetBytes function.
 var SetBytes = config.SetBytes
 
@@ -38,3 +52,23 @@ var FuncSummaries = summary.FuncSummaries
 // InterfaceFuncSummaries is a wrapper around the propagation/summary
 // package's map of interface function summaries.
 var InterfaceFuncSummaries = summary.InterfaceFuncSummaries
+
+// ExtendedSummary is a wrapper around the propagation/summary
+// package's Extended type, whose positions are access paths.
+type ExtendedSummary = summary.Extended
+
+// Path is a wrapper around the propagation/summary package's Path type.
+type Path = summary.Path
+
+// Access is a wrapper around the propagation/summary package's Access type.
+type Access = summary.Access
+
+// At is a wrapper around the propagation/summary package's At function.
+var At = summary.At
+
+// AnyElement is a wrapper around the propagation/summary package's AnyElement constant.
+const AnyElement = summary.AnyElement
+
+// ExtendedFuncSummaries is a wrapper around the propagation/summary
+// package's map of summaries that need access paths.
+var ExtendedFuncSummaries = summary.ExtendedFuncSummaries